@@ -0,0 +1,166 @@
+package tistoryAPI
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// defaultAttachConcurrency AttachMany 기본 동시 업로드 수
+const defaultAttachConcurrency = 4
+
+// AttachProgress 파일 하나의 업로드 진행 상황
+// Path		업로드 중인 파일 경로
+// Sent		지금까지 보낸 바이트 수
+// Total	파일 크기
+type AttachProgress struct {
+	// Path 업로드 중인 파일 경로
+	Path string
+
+	// Sent 지금까지 보낸 바이트 수
+	Sent int64
+
+	// Total 파일 크기
+	Total int64
+}
+
+// AttachOption AttachMany 설정 옵션
+type AttachOption func(*attachOptions)
+
+type attachOptions struct {
+	concurrency int
+	progress    func(AttachProgress)
+}
+
+// WithConcurrency 동시에 업로드할 파일 수 (기본값: 4)
+func WithConcurrency(n int) AttachOption {
+	return func(o *attachOptions) {
+		if n > 0 {
+			o.concurrency = n
+		}
+	}
+}
+
+// WithProgress 업로드 진행 상황 콜백
+// 여러 파일이 동시에 올라가더라도 콜백은 한번에 하나씩만 호출된다.
+func WithProgress(fn func(AttachProgress)) AttachOption {
+	return func(o *attachOptions) {
+		o.progress = fn
+	}
+}
+
+// AttachManyResult AttachMany 결과
+// Results	paths 와 같은 순서의 첨부 결과 (실패한 파일은 빈 값)
+// Errors	실패한 파일의 에러 (key: paths 의 인덱스, 같은 경로가 여러번 있어도 따로 담긴다)
+type AttachManyResult struct {
+	// Results paths 와 같은 순서의 첨부 결과 (실패한 파일은 빈 값)
+	Results []model.AttachResult
+
+	// Errors 실패한 파일의 에러 (key: paths 의 인덱스, 같은 경로가 여러번 있어도 따로 담긴다)
+	Errors map[int]error
+}
+
+func (s service) AttachMany(ctx context.Context, blogName string, paths []string, opts ...AttachOption) (AttachManyResult, error) {
+	o := attachOptions{concurrency: defaultAttachConcurrency}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	result := AttachManyResult{Results: make([]model.AttachResult, len(paths)), Errors: map[int]error{}}
+	errs := make([]error, len(paths))
+
+	var (
+		wg         sync.WaitGroup
+		progressMu sync.Mutex
+		sem        = make(chan struct{}, o.concurrency)
+	)
+	report := func(p AttachProgress) {
+		if o.progress == nil {
+			return
+		}
+		progressMu.Lock()
+		defer progressMu.Unlock()
+		o.progress(p)
+	}
+
+	for i, path := range paths {
+		// 취소되면 남은 파일은 올리지 않고 ctx 에러로 채운다.
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, path string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			result.Results[i], errs[i] = s.attachWithProgress(ctx, blogName, path, report)
+		}(i, path)
+	}
+	wg.Wait()
+
+	var joined []error
+	for i, err := range errs {
+		if err != nil {
+			result.Errors[i] = err
+			joined = append(joined, fmt.Errorf("%s: %w", paths[i], err))
+		}
+	}
+	return result, errors.Join(joined...)
+}
+
+// attachWithProgress 파일 하나를 올리면서 읽은 만큼 진행 상황을 알린다.
+func (s service) attachWithProgress(ctx context.Context, blogName, path string, report func(AttachProgress)) (model.AttachResult, error) {
+	openedFile, err := os.Open(path)
+	if err != nil {
+		return model.AttachResult{}, err
+	}
+	defer openedFile.Close()
+
+	info, err := openedFile.Stat()
+	if err != nil {
+		return model.AttachResult{}, err
+	}
+
+	progress := AttachProgress{Path: path, Total: info.Size()}
+	report(progress)
+	wrap := func(r io.Reader) io.Reader {
+		return &progressReader{r: r, onRead: func(n int) {
+			progress.Sent += int64(n)
+			report(progress)
+		}}
+	}
+
+	result, err := s.attachCached(ctx, blogName, filepath.Base(path), openedFile, wrap)
+	if err == nil && progress.Sent < progress.Total {
+		// 캐시에서 찾아 올리지 않은 경우에도 완료로 알린다.
+		progress.Sent = progress.Total
+		report(progress)
+	}
+	return result, err
+}
+
+// progressReader 읽을 때마다 읽은 바이트 수를 알리는 io.Reader
+type progressReader struct {
+	r      io.Reader
+	onRead func(n int)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.onRead(n)
+	}
+	return n, err
+}
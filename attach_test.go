@@ -0,0 +1,77 @@
+package tistoryAPI
+
+import (
+	"context"
+	"fmt"
+	"github.com/fineroot1253/tistoryAPI/tistorytest"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestService_AttachMany(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for i := 0; i < 12; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("screenshot-%02d.png", i))
+		if err := os.WriteFile(path, []byte(strings.Repeat("x", 1000*(i+1))), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	missing := filepath.Join(dir, "missing.png")
+
+	t.Run("전체 성공:[success]", func(t *testing.T) {
+		serv, srv := newTestService(t)
+
+		progress := map[string]AttachProgress{}
+		got, err := serv.AttachMany(context.Background(), tistorytest.BlogName, paths,
+			WithConcurrency(3),
+			WithProgress(func(p AttachProgress) {
+				assert.LessOrEqual(t, p.Sent, p.Total)
+				progress[p.Path] = p
+			}))
+		assert.NoError(t, err)
+		assert.Empty(t, got.Errors)
+		assert.Len(t, srv.Attachments(), len(paths))
+
+		// 결과는 입력 순서, 진행 상황은 파일 끝까지
+		byName := map[string]string{}
+		for _, attachment := range srv.Attachments() {
+			byName[attachment.Name] = attachment.Result.Url
+		}
+		for i, path := range paths {
+			assert.Equal(t, byName[filepath.Base(path)], got.Results[i].Url, path)
+			assert.Equal(t, int64(1000*(i+1)), progress[path].Sent, path)
+			assert.Equal(t, progress[path].Total, progress[path].Sent, path)
+		}
+	})
+
+	t.Run("일부 실패:[failure]", func(t *testing.T) {
+		serv, _ := newTestService(t)
+
+		input := []string{paths[0], missing, paths[1], missing}
+		got, err := serv.AttachMany(context.Background(), tistorytest.BlogName, input)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+		assert.Len(t, got.Errors, 2)
+		assert.ErrorIs(t, got.Errors[1], os.ErrNotExist)
+		assert.ErrorIs(t, got.Errors[3], os.ErrNotExist)
+		assert.NotEmpty(t, got.Results[0].Url)
+		assert.Empty(t, got.Results[1].Url)
+		assert.NotEmpty(t, got.Results[2].Url)
+		assert.Empty(t, got.Results[3].Url)
+	})
+
+	t.Run("취소:[failure]", func(t *testing.T) {
+		serv, srv := newTestService(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		got, err := serv.AttachMany(ctx, tistorytest.BlogName, paths)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Len(t, got.Errors, len(paths))
+		assert.Empty(t, srv.Attachments())
+	})
+}
@@ -0,0 +1,135 @@
+package tistoryAPI
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"io"
+	"sync"
+)
+
+// ErrAttachmentNotFound 캐시에 첨부 결과가 없는 경우
+var ErrAttachmentNotFound = errors.New("tistoryAPI: attachment not found")
+
+// AttachmentKey 첨부 캐시 키
+// BlogName	블로그 명 (같은 파일이라도 블로그가 다르면 따로 올린다)
+// Hash		파일 내용의 SHA-256 (hex)
+type AttachmentKey struct {
+	// BlogName 블로그 명 (같은 파일이라도 블로그가 다르면 따로 올린다)
+	BlogName string
+
+	// Hash 파일 내용의 SHA-256 (hex)
+	Hash string
+}
+
+func (k AttachmentKey) String() string {
+	return k.BlogName + "/" + k.Hash
+}
+
+// AttachmentCache 첨부 결과 캐시 인터페이스
+// WithAttachmentCache 옵션으로 넘기면 AttachFiles, AttachReader, AttachMany 가 같은 내용의 파일을 다시 올리지 않고 캐시된 결과를 쓴다.
+type AttachmentCache interface {
+	// Get 캐시된 첨부 결과 읽기 (없으면 ErrAttachmentNotFound)
+	Get(key AttachmentKey) (model.AttachResult, error)
+	// Put 첨부 결과 저장 (같은 키는 덮어쓴다)
+	Put(key AttachmentKey, result model.AttachResult) error
+}
+
+// NewMemoryAttachmentCache 프로세스 메모리 첨부 캐시 (재시작하면 비워진다)
+// return AttachmentCache
+func NewMemoryAttachmentCache() AttachmentCache {
+	return &memoryAttachmentCache{results: map[AttachmentKey]model.AttachResult{}}
+}
+
+// NewFileAttachmentCache JSON 파일 첨부 캐시
+// 재시작 후에도 같은 이미지를 다시 올리지 않도록 결과를 파일에 남긴다.
+// @Param string	// 저장 파일 경로
+// return AttachmentCache
+func NewFileAttachmentCache(path string) AttachmentCache {
+	return &fileAttachmentCache{file: jsonFile[model.AttachResult]{path: path}}
+}
+
+type memoryAttachmentCache struct {
+	mu      sync.RWMutex
+	results map[AttachmentKey]model.AttachResult
+}
+
+func (m *memoryAttachmentCache) Get(key AttachmentKey) (model.AttachResult, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result, ok := m.results[key]
+	if !ok {
+		return model.AttachResult{}, ErrAttachmentNotFound
+	}
+	return result, nil
+}
+
+func (m *memoryAttachmentCache) Put(key AttachmentKey, result model.AttachResult) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.results[key] = result
+	return nil
+}
+
+// fileAttachmentCache 키별 첨부 결과를 하나의 JSON 파일에 저장한다.
+type fileAttachmentCache struct {
+	mu   sync.Mutex
+	file jsonFile[model.AttachResult]
+}
+
+func (f *fileAttachmentCache) Get(key AttachmentKey) (model.AttachResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	results, err := f.file.read()
+	if err != nil {
+		return model.AttachResult{}, err
+	}
+	result, ok := results[key.String()]
+	if !ok {
+		return model.AttachResult{}, ErrAttachmentNotFound
+	}
+	return result, nil
+}
+
+func (f *fileAttachmentCache) Put(key AttachmentKey, result model.AttachResult) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	results, err := f.file.read()
+	if err != nil {
+		return err
+	}
+	results[key.String()] = result
+	return f.file.write(results)
+}
+
+// hashContent r 의 SHA-256 을 구하고, 업로드에 다시 쓸 수 있는 reader 를 돌려준다.
+// r 이 io.ReadSeeker 면 처음 위치로 되돌려서 그대로 쓰고, 아니면 내용을 메모리에 담는다.
+func hashContent(r io.Reader) (string, io.Reader, error) {
+	hash := sha256.New()
+
+	if seeker, ok := r.(io.ReadSeeker); ok {
+		offset, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return "", nil, err
+		}
+		if _, err := io.Copy(hash, seeker); err != nil {
+			return "", nil, err
+		}
+		if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+			return "", nil, err
+		}
+		return hex.EncodeToString(hash.Sum(nil)), seeker, nil
+	}
+
+	buf := &bytes.Buffer{}
+	if _, err := io.Copy(io.MultiWriter(hash, buf), r); err != nil {
+		return "", nil, err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), buf, nil
+}
@@ -0,0 +1,154 @@
+package tistoryAPI
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"github.com/fineroot1253/tistoryAPI/tistorytest"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/iotest"
+)
+
+func TestAttachmentCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "attachments.json")
+	tests := []struct {
+		name  string
+		cache AttachmentCache
+		// reopen 같은 저장소를 다시 열었을 때 (재시작)
+		reopen func() AttachmentCache
+	}{
+		{
+			name:  "메모리 캐시:[success]",
+			cache: NewMemoryAttachmentCache(),
+		},
+		{
+			name:   "파일 캐시:[success]",
+			cache:  NewFileAttachmentCache(path),
+			reopen: func() AttachmentCache { return NewFileAttachmentCache(path) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := AttachmentKey{BlogName: "blog", Hash: "abc"}
+			result := model.AttachResult{Status: "200", Url: "https://cfile.tistory.com/image/abc.png", Replacer: "[##_1N|cfile1.uf@abc.png_##]"}
+
+			_, err := tt.cache.Get(key)
+			assert.ErrorIs(t, err, ErrAttachmentNotFound)
+
+			assert.NoError(t, tt.cache.Put(key, result))
+			got, err := tt.cache.Get(key)
+			assert.NoError(t, err)
+			assert.Equal(t, result, got)
+
+			_, err = tt.cache.Get(AttachmentKey{BlogName: "other", Hash: "abc"})
+			assert.ErrorIs(t, err, ErrAttachmentNotFound)
+
+			if tt.reopen != nil {
+				got, err = tt.reopen().Get(key)
+				assert.NoError(t, err)
+				assert.Equal(t, result, got)
+
+				info, err := os.Stat(path)
+				assert.NoError(t, err)
+				assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+			}
+		})
+	}
+}
+
+func TestService_AttachmentCache(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "gopher.png")
+	if err := os.WriteFile(filePath, []byte("\x89PNG gopher"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := tistorytest.NewServer()
+	defer srv.Close()
+	cache := NewFileAttachmentCache(filepath.Join(dir, "attachments.json"))
+	newCachedService := func(opts ...Option) Service {
+		serv, err := NewService(context.Background(), srv.UserData(),
+			append([]Option{WithBaseURL(srv.APIURL()), WithOAuthURL(srv.OAuthURL()), WithAttachmentCache(cache)}, opts...)...)
+		assert.NoError(t, err)
+		return serv
+	}
+	serv := newCachedService()
+
+	first, err := serv.AttachFiles(tistorytest.BlogName, filePath)
+	assert.NoError(t, err)
+	assert.Len(t, srv.Attachments(), 1)
+
+	// 같은 파일, 같은 내용의 reader (Seeker 아님) 는 다시 올리지 않는다.
+	again, err := serv.AttachFiles(tistorytest.BlogName, filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, first, again)
+	fromReader, err := serv.AttachReader(context.Background(), tistorytest.BlogName, "copy.png", iotest.OneByteReader(bytes.NewBufferString("\x89PNG gopher")))
+	assert.NoError(t, err)
+	assert.Equal(t, first, fromReader)
+	many, err := serv.AttachMany(context.Background(), tistorytest.BlogName, []string{filePath})
+	assert.NoError(t, err)
+	assert.Equal(t, first, many.Results[0])
+	assert.Len(t, srv.Attachments(), 1)
+
+	// 내용이 다르면 새로 올린다.
+	other, err := serv.AttachReader(context.Background(), tistorytest.BlogName, "other.png", bytes.NewReader([]byte("other")))
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.Url, other.Url)
+	assert.Len(t, srv.Attachments(), 2)
+
+	// 강제 갱신은 캐시를 읽지 않고 올린 뒤 캐시를 갱신한다.
+	refreshed, err := newCachedService(WithAttachmentRefresh()).AttachFiles(tistorytest.BlogName, filePath)
+	assert.NoError(t, err)
+	assert.Len(t, srv.Attachments(), 3)
+	assert.NotEqual(t, first.Replacer, refreshed.Replacer)
+
+	cached, err := serv.AttachFiles(tistorytest.BlogName, filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, refreshed, cached)
+}
+
+func TestService_AttachManyCache(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for i := 0; i < 8; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("copy-%d.png", i))
+		if err := os.WriteFile(path, []byte("\x89PNG gopher"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	serv, srv := newTestService(t, WithAttachmentCache(NewMemoryAttachmentCache()))
+
+	// 같은 내용의 파일을 동시에 올려도 한번만 올린다.
+	got, err := serv.AttachMany(context.Background(), tistorytest.BlogName, paths, WithConcurrency(len(paths)))
+	assert.NoError(t, err)
+	assert.Len(t, srv.Attachments(), 1)
+	for i := range paths {
+		assert.Equal(t, srv.Attachments()[0].Result, got.Results[i], paths[i])
+	}
+}
+
+func TestHashContent(t *testing.T) {
+	content := []byte("gopher")
+
+	// Seeker 는 읽은 뒤 원래 위치로 되돌린다.
+	seeker := bytes.NewReader(content)
+	hash, body, err := hashContent(seeker)
+	assert.NoError(t, err)
+	assert.Same(t, seeker, body)
+	data, err := io.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, content, data)
+
+	hash2, body, err := hashContent(io.NopCloser(bytes.NewReader(content)))
+	assert.NoError(t, err)
+	assert.Equal(t, hash, hash2)
+	data, err = io.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, content, data)
+}
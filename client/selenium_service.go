@@ -4,16 +4,19 @@ import (
 	"fmt"
 
 	"github.com/fineroot1253/tistoryAPI"
+	"github.com/fineroot1253/tistoryAPI/internal/state"
 	"github.com/fineroot1253/tistoryAPI/model"
 	"github.com/tebeka/selenium"
 	"github.com/tebeka/selenium/chrome"
 	"log"
-	"math/rand"
 	"net/url"
 	"strconv"
 	"time"
 )
 
+// ErrStateMismatch 리다이렉트된 URL 의 state 가 인증 요청의 state 와 다른 경우
+var ErrStateMismatch = state.ErrMismatch
+
 type Service interface {
 	GetAuthorizeCode(authData model.UserAuthData, sleepTime time.Duration, debugMode bool) (string, error)
 }
@@ -22,16 +25,25 @@ type service struct {
 	driverPath          string
 	port                int
 	startUrl            string
+	state               string
 	chromeDriverService selenium.Service
 }
 
 func NewService(driverPath string, port int, userData model.UserData, serviceOpts []selenium.ServiceOption) (Service, error) {
 
+	// CSRF 방지용 state, 리다이렉트된 URL 에서 다시 검증한다.
+	stateToken, err := state.Gen()
+	if err != nil {
+		return nil, err
+	}
+
 	// 초기 로딩 URL
-	startUrl := tistoryAPI.TISTORY_OAUTH_AUTHENTICATIONTOKEN_GET_PATH +
-		"?client_id=" + userData.ClientId +
-		"&redirect_uri=" + userData.RedirectUrl +
-		"&response_type=code"
+	query := url.Values{}
+	query.Set("client_id", userData.ClientId)
+	query.Set("redirect_uri", userData.RedirectUrl)
+	query.Set("response_type", "code")
+	query.Set("state", stateToken)
+	startUrl := tistoryAPI.TISTORY_OAUTH_AUTHENTICATIONTOKEN_GET_PATH + "?" + query.Encode()
 
 	// option을 통해 셀레니움 초기화
 	// 크롬만 쓰도록 강제하기 위해 드라이버 서비스만 주입받도록 만들지 않았다.
@@ -41,7 +53,7 @@ func NewService(driverPath string, port int, userData model.UserData, serviceOpt
 		return nil, err2
 	}
 
-	return service{driverPath: driverPath, port: port, startUrl: startUrl, chromeDriverService: *chromeDriverService}, nil
+	return service{driverPath: driverPath, port: port, startUrl: startUrl, state: stateToken, chromeDriverService: *chromeDriverService}, nil
 }
 
 // GetAuthorizeCode 크롬 드라이버를 통해 Authentication code를 구하는 연산
@@ -49,6 +61,7 @@ func NewService(driverPath string, port int, userData model.UserData, serviceOpt
 // sleepTime을 통해 Sec 단위로 대기 시간을 조절할 수 있다.
 // 너무 짦으면 현재 드라이버가 막힐 수도 있다. 이땐 새로운 셀레니움 서비스를 얻어야한다.
 // debugMode를 통해 유닛 테스트으로써 실행후 드라이버를 종료할 것인지 정할 수 있다.
+// 리다이렉트된 URL 의 state 가 다르면 ErrStateMismatch 를 반환한다.
 func (s service) GetAuthorizeCode(authData model.UserAuthData, sleepTime time.Duration, debugMode bool) (string, error) {
 
 	authorizeCode := ""
@@ -156,28 +169,35 @@ func (s service) GetAuthorizeCode(authData model.UserAuthData, sleepTime time.Du
 		log.Panicln(err)
 		return authorizeCode, err
 	}
+
+	return s.parseAuthorizeCode(resultUrl)
+
+}
+
+// parseAuthorizeCode 리다이렉트된 URL 에서 허가코드를 꺼낸다.
+// state 가 다르면 다른 요청의 code 이므로 받지 않고 ErrStateMismatch 를 반환한다.
+func (s service) parseAuthorizeCode(resultUrl string) (string, error) {
 	u, err := url.Parse(resultUrl)
 	if err != nil {
-		log.Panicln(err)
-		return authorizeCode, err
+		return "", err
 	}
 	parseQuery, err := url.ParseQuery(u.RawQuery)
 	if err != nil {
-		log.Panicln(err)
-		return authorizeCode, err
+		return "", err
 	}
 
-	authorizeCode = parseQuery.Get("code")
-
-	return authorizeCode, nil
+	if err := state.Verify(s.state, parseQuery.Get("state")); err != nil {
+		return "", err
+	}
 
+	return parseQuery.Get("code"), nil
 }
 
 func genTxId(appKey string) (string, error) {
 
 	var resultStr string
 
-	prefix, err2 := getRandomString()
+	prefix, err2 := state.RandomString()
 	if err2 != nil {
 		return resultStr, err2
 	}
@@ -190,41 +210,5 @@ func genTxId(appKey string) (string, error) {
 
 }
 
-// StateToken 생성기
-func genStateToken() (string, error) {
-
-	var resultStr string
-
-	prefix, err1 := getRandomString()
-	if err1 != nil {
-		return resultStr, err1
-	}
-
-	suffix, err2 := getRandomString()
-	if err2 != nil {
-		return resultStr, err2
-	}
-
-	resultStr = prefix + suffix
-
-	return resultStr, nil
-}
-
-// Float64기준 소수자리 숫자 => Base36 문자화 하는 로직
-func getRandomString() (string, error) {
-	var resultStr string
-
-	randNum := rand.Float64()
-	randStrWithDot := fmt.Sprintf("%v", randNum)
-	randStr := randStrWithDot[2:]
-	parseUint, err := strconv.ParseUint(randStr, 10, 0)
-	if err != nil {
-		return resultStr, err
-	}
-	resultStr = strconv.FormatUint(parseUint, 36)
-
-	return resultStr, nil
-}
-
 //func var tranId = Math.random().toString(36).slice(2) + getAppKey$1() + Date.now().toString(36);
 //return tranId.slice(0, 60);
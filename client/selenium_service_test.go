@@ -1,6 +1,7 @@
 package client
 
 import (
+	"github.com/fineroot1253/tistoryAPI/internal/state"
 	"github.com/fineroot1253/tistoryAPI/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/tebeka/selenium"
@@ -12,6 +13,12 @@ import (
 
 func Test_service_GetAuthorizeCode(t *testing.T) {
 
+	// 크롬 드라이버가 있는 환경에서만 실행한다.
+	driverPath := os.Getenv("CHROMEDRIVER_PATH")
+	if driverPath == "" {
+		t.Skip("CHROMEDRIVER_PATH 없음")
+	}
+
 	userData := model.UserData{
 		SecretKey:   "de3c1ccd0e25901befb05cd326aa5257b85463d538cea7156c6744ad75e0a29ebd95ffad",
 		ClientId:    "de3c1ccd0e25901befb05cd326aa5257",
@@ -24,7 +31,7 @@ func Test_service_GetAuthorizeCode(t *testing.T) {
 	}
 
 	service, err := NewService(
-		"/Users/hongjungeun/go/src/github.com/fineroot1253/tistoryAPI/bin/chromeDriver/chromedriver",
+		driverPath,
 		7777,
 		userData,
 		[]selenium.ServiceOption{selenium.Output(os.Stderr)},
@@ -66,3 +73,49 @@ func Test_service_GetAuthorizeCode(t *testing.T) {
 		log.Println(tt.name, " ==> auth code: ", code)
 	}
 }
+
+func Test_service_parseAuthorizeCode(t *testing.T) {
+	stateToken, err := state.Gen()
+	assert.NoError(t, err)
+	s := service{state: stateToken}
+
+	tests := []struct {
+		name      string
+		resultUrl string
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "state 일치:[success]",
+			resultUrl: "https://fineroot1253.tistory.com/?code=auth-code&state=" + stateToken,
+			want:      "auth-code",
+		},
+		{
+			name:      "state 불일치:[failure]",
+			resultUrl: "https://fineroot1253.tistory.com/?code=auth-code&state=forged",
+			wantErr:   ErrStateMismatch,
+		},
+		{
+			name:      "state 누락:[failure]",
+			resultUrl: "https://fineroot1253.tistory.com/?code=auth-code",
+			wantErr:   ErrStateMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := s.parseAuthorizeCode(tt.resultUrl)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, code)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, code)
+		})
+	}
+
+	other, err := state.Gen()
+	assert.NoError(t, err)
+	assert.NotEqual(t, stateToken, other)
+	assert.GreaterOrEqual(t, len(stateToken), 20)
+}
@@ -0,0 +1,141 @@
+package tistoryAPI
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// 실패 종류 판별용 센티넬 에러
+// errors.Is(err, ErrUnauthorized) 처럼 APIError 를 분기할 때 사용한다.
+var (
+	ErrBadRequest   = errors.New("tistoryAPI: bad request")
+	ErrUnauthorized = errors.New("tistoryAPI: unauthorized")
+	ErrForbidden    = errors.New("tistoryAPI: forbidden")
+	ErrNotFound     = errors.New("tistoryAPI: not found")
+	ErrRateLimited  = errors.New("tistoryAPI: rate limited")
+	ErrServer       = errors.New("tistoryAPI: server error")
+)
+
+// ErrCategoryNotFound ResolveCategory 에서 경로에 맞는 카테고리가 없는 경우
+var ErrCategoryNotFound = errors.New("tistoryAPI: category not found")
+
+// ErrCategoryAmbiguous ResolveCategory 에서 이름이 같은 카테고리가 여러 개라 하나로 정할 수 없는 경우
+var ErrCategoryAmbiguous = errors.New("tistoryAPI: ambiguous category name")
+
+// ErrImageOutsideBaseDir PublishWithAssets 에서 이미지 경로가 baseDir 밖을 가리키는 경우
+var ErrImageOutsideBaseDir = errors.New("tistoryAPI: image path outside base directory")
+
+// ErrInvalidImageSrc PublishWithAssets 에서 img 태그의 src 를 URL 로 해석할 수 없는 경우
+var ErrInvalidImageSrc = errors.New("tistoryAPI: invalid image src")
+
+// 토큰 발급 실패 종류 판별용 센티넬 에러
+// errors.Is(err, ErrInvalidGrant) 처럼 OAuthError 를 분기할 때 사용한다.
+var (
+	ErrInvalidRequest      = errors.New("tistoryAPI: oauth invalid_request")
+	ErrInvalidClient       = errors.New("tistoryAPI: oauth invalid_client")
+	ErrInvalidGrant        = errors.New("tistoryAPI: oauth invalid_grant")
+	ErrRedirectURIMismatch = errors.New("tistoryAPI: oauth redirect_uri_mismatch")
+)
+
+// APIError Tistory API 실패 응답 에러 타입
+// HTTPStatus	HTTP 상태코드
+// Status		Tistory 응답의 status 값 (ex: "400")
+// Message		Tistory 응답의 error_message 값
+// Endpoint		요청한 API 경로 (ex: "/apis/post/write")
+// RequestID	응답 헤더의 요청 ID (없으면 빈 문자열)
+type APIError struct {
+	// HTTPStatus HTTP 상태코드
+	HTTPStatus int
+
+	// Status Tistory 응답의 status 값 (ex: "400")
+	Status string
+
+	// Message Tistory 응답의 error_message 값
+	Message string
+
+	// Endpoint 요청한 API 경로 (ex: "/apis/post/write")
+	Endpoint string
+
+	// RequestID 응답 헤더의 요청 ID (없으면 빈 문자열)
+	RequestID string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("tistoryAPI: %s failed (http %d, status %s)", e.Endpoint, e.HTTPStatus, e.Status)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.RequestID != "" {
+		msg += " [request id " + e.RequestID + "]"
+	}
+	return msg
+}
+
+// Is 센티넬 에러와 비교한다.
+// Tistory 는 HTTP 200 으로 실패를 내려주는 경우가 있어 Tistory status 값을 우선으로 판단한다.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.code() == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.code() == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.code() == http.StatusForbidden
+	case ErrNotFound:
+		return e.code() == http.StatusNotFound
+	case ErrRateLimited:
+		return e.code() == http.StatusTooManyRequests
+	case ErrServer:
+		return e.code() >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// code 판단 기준이 되는 상태코드
+// Tistory status 값이 숫자가 아니거나 성공값이면 HTTP 상태코드를 사용한다.
+func (e *APIError) code() int {
+	if status, err := strconv.Atoi(e.Status); err == nil && status >= http.StatusBadRequest {
+		return status
+	}
+	return e.HTTPStatus
+}
+
+// OAuthError 토큰 발급 실패 에러 타입
+// HTTPStatus	HTTP 상태코드
+// Code			OAuth 에러 코드 (ex: invalid_grant, redirect_uri_mismatch)
+// Description	에러 설명 (error_description)
+type OAuthError struct {
+	// HTTPStatus HTTP 상태코드
+	HTTPStatus int
+
+	// Code OAuth 에러 코드 (ex: invalid_grant, redirect_uri_mismatch)
+	Code string
+
+	// Description 에러 설명 (error_description)
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	msg := fmt.Sprintf("tistoryAPI: oauth %s (http %d)", e.Code, e.HTTPStatus)
+	if e.Description != "" {
+		msg += ": " + e.Description
+	}
+	return msg
+}
+
+// Is 센티넬 에러와 비교한다.
+func (e *OAuthError) Is(target error) bool {
+	switch target {
+	case ErrInvalidRequest:
+		return e.Code == "invalid_request"
+	case ErrInvalidClient:
+		return e.Code == "invalid_client"
+	case ErrInvalidGrant:
+		return e.Code == "invalid_grant"
+	case ErrRedirectURIMismatch:
+		return e.Code == "redirect_uri_mismatch"
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package tistoryAPI
+
+import (
+	"errors"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		name       string
+		httpStatus int
+		body       string
+		wantStatus string
+		wantErr    error
+		wantMsg    string
+	}{
+		{
+			name:       "정상 응답:[success]",
+			httpStatus: http.StatusOK,
+			body:       `{"tistory":{"status":"200","postId":"74","url":"http://sample.tistory.com/74"}}`,
+			wantStatus: "200",
+		},
+		{
+			name:       "HTTP 200 + Tistory 400 응답:[failure]",
+			httpStatus: http.StatusOK,
+			body:       `{"tistory":{"status":"400","error_message":"블로그 정보가 없습니다."}}`,
+			wantErr:    ErrBadRequest,
+			wantMsg:    "블로그 정보가 없습니다.",
+		},
+		{
+			name:       "토큰 만료 응답:[failure]",
+			httpStatus: http.StatusUnauthorized,
+			body:       `{"tistory":{"status":"401","error_message":"access_token 이 유효하지 않습니다."}}`,
+			wantErr:    ErrUnauthorized,
+			wantMsg:    "access_token 이 유효하지 않습니다.",
+		},
+		{
+			name:       "없는 글 응답:[failure]",
+			httpStatus: http.StatusNotFound,
+			body:       `{"tistory":{"status":"404","error_message":"존재하지 않는 글입니다."}}`,
+			wantErr:    ErrNotFound,
+			wantMsg:    "존재하지 않는 글입니다.",
+		},
+		{
+			name:       "요청 제한 HTML 응답:[failure]",
+			httpStatus: http.StatusTooManyRequests,
+			body:       `<html><body>Too Many Requests</body></html>`,
+			wantErr:    ErrRateLimited,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Request-Id", "req-1")
+				w.WriteHeader(tt.httpStatus)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			resp, err := srv.Client().Get(srv.URL + "/apis/post/write")
+			assert.NoError(t, err)
+
+			got := model.PostWriteResult{}
+			err = compute[model.PostWriteResult, model.EmptyType, model.EmptyType](&got, resp)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantStatus, got.Status)
+				assert.Equal(t, "74", got.PostId)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			var apiErr *APIError
+			assert.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, tt.httpStatus, apiErr.HTTPStatus)
+			assert.Equal(t, tt.wantMsg, apiErr.Message)
+			assert.Equal(t, "/apis/post/write", apiErr.Endpoint)
+			assert.Equal(t, "req-1", apiErr.RequestID)
+		})
+	}
+}
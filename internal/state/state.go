@@ -0,0 +1,51 @@
+// Package state OAuth 인증 요청의 state 토큰 생성기
+// client, oauth 패키지가 함께 사용한다.
+package state
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"strconv"
+)
+
+// ErrMismatch 리다이렉트된 state 가 요청한 state 와 다른 경우
+var ErrMismatch = errors.New("tistoryAPI: oauth state mismatch")
+
+// Verify 리다이렉트된 state 가 요청한 state 와 같은지 검사한다.
+func Verify(expected, got string) error {
+	if expected == "" || subtle.ConstantTimeCompare([]byte(expected), []byte(got)) != 1 {
+		return ErrMismatch
+	}
+	return nil
+}
+
+// Gen StateToken 생성기 (128bit, CSRF 방지용)
+func Gen() (string, error) {
+
+	var resultStr string
+
+	prefix, err1 := RandomString()
+	if err1 != nil {
+		return resultStr, err1
+	}
+
+	suffix, err2 := RandomString()
+	if err2 != nil {
+		return resultStr, err2
+	}
+
+	resultStr = prefix + suffix
+
+	return resultStr, nil
+}
+
+// RandomString crypto/rand 로 뽑은 64bit 난수 => Base36 문자화 하는 로직
+func RandomString() (string, error) {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", err
+	}
+	return strconv.FormatUint(binary.BigEndian.Uint64(buf[:]), 36), nil
+}
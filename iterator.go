@@ -0,0 +1,155 @@
+package tistoryAPI
+
+import (
+	"context"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"time"
+)
+
+// Iterator 페이지 단위 목록 API 를 한 건씩 훑는 iterator
+// 다음 페이지는 Next 가 현재 페이지를 다 소비했을 때에만 요청한다. (지연 요청)
+// 사용법:
+//
+//	it := s.PostIter(ctx, blogName)
+//	for it.Next() {
+//		post := it.Item()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[T any] struct {
+	ctx context.Context
+
+	// fetch page 번째 페이지 요청, last 가 true 면 마지막 페이지
+	fetch func(ctx context.Context, page int) (items []T, last bool, err error)
+	// filter false 를 반환한 항목은 건너뛴다. (nil 이면 모두 통과)
+	filter func(item T) bool
+
+	page  int
+	items []T
+	item  T
+	last  bool
+	err   error
+}
+
+// newIterator fetch 로 페이지를 받아오는 Iterator 생성
+func newIterator[T any](ctx context.Context, fetch func(ctx context.Context, page int) ([]T, bool, error), filter func(T) bool) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, fetch: fetch, filter: filter}
+}
+
+// Next 다음 항목으로 이동
+// 더 이상 항목이 없거나 에러가 발생하면 false 를 반환한다. 에러는 Err 로 확인한다.
+func (it *Iterator[T]) Next() bool {
+	for it.err == nil {
+		for len(it.items) > 0 {
+			item := it.items[0]
+			it.items = it.items[1:]
+			if it.filter == nil || it.filter(item) {
+				it.item = item
+				return true
+			}
+		}
+		if it.last {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		it.page++
+		it.items, it.last, it.err = it.fetch(it.ctx, it.page)
+	}
+	return false
+}
+
+// Item 현재 항목 (Next 가 true 를 반환한 뒤에만 유효하다)
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err 순회 중 발생한 에러 (정상 종료면 nil)
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// PostIterOption PostIter 필터 옵션
+type PostIterOption func(*postIterOptions)
+
+type postIterOptions struct {
+	categoryId string
+	visibility model.Visibility
+}
+
+// WithCategoryFilter 카테고리 ID 가 같은 글만 순회한다.
+func WithCategoryFilter(categoryId string) PostIterOption {
+	return func(o *postIterOptions) {
+		o.categoryId = categoryId
+	}
+}
+
+// WithVisibilityFilter 공개 여부 상태가 같은 글만 순회한다. (model.Private, model.Protected, model.Public)
+func WithVisibilityFilter(visibility model.Visibility) PostIterOption {
+	return func(o *postIterOptions) {
+		o.visibility = visibility
+	}
+}
+
+func (o postIterOptions) match(post model.PostListItemData) bool {
+	if o.categoryId != "" && post.CategoryId != o.categoryId {
+		return false
+	}
+	if o.visibility != "" && post.Visibility != o.visibility {
+		return false
+	}
+	return true
+}
+
+func (s service) PostIter(ctx context.Context, blogName string, opts ...PostIterOption) *Iterator[model.PostListItemData] {
+	o := postIterOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// 필터는 받아온 페이지에 적용되므로 TotalCount 는 필터와 상관 없이 전체 글 수로 비교한다.
+	seen := 0
+	fetch := func(ctx context.Context, page int) ([]model.PostListItemData, bool, error) {
+		result, err := s.GetPostListContext(ctx, blogName, page)
+		if err != nil {
+			return nil, true, err
+		}
+		posts := result.Item.Posts
+		seen += len(posts)
+		return posts, isLastPostPage(result.Item, seen), nil
+	}
+	return newIterator(ctx, fetch, o.match)
+}
+
+// isLastPostPage 빈 페이지를 받았거나 지금까지 받은 글 수가 TotalCount 에 도달하면 마지막 페이지
+// TotalCount 가 없으면(0) 빈 페이지가 올 때까지 요청한다.
+func isLastPostPage(item model.PostListItem, seen int) bool {
+	if len(item.Posts) == 0 {
+		return true
+	}
+	return item.TotalCount > 0 && seen >= item.TotalCount.Int()
+}
+
+// newestCommentPageSize 최신 댓글 목록 API 의 페이지당 최대 댓글 수
+const newestCommentPageSize = 10
+
+func (s service) NewestComments(ctx context.Context, blogName string, since time.Time) *Iterator[model.CommentNewestItemData] {
+	fetch := func(ctx context.Context, page int) ([]model.CommentNewestItemData, bool, error) {
+		result, err := s.GetNewestCommentListContext(ctx, blogName, page, newestCommentPageSize)
+		if err != nil {
+			return nil, true, err
+		}
+		comments := result.Item.Comments.Comment
+
+		// 최신순이므로 since 이전 댓글이 나오면 그 뒤는 볼 필요가 없다.
+		for i, comment := range comments {
+			if !comment.Date.After(since) {
+				return comments[:i], true, nil
+			}
+		}
+		return comments, len(comments) < newestCommentPageSize, nil
+	}
+	return newIterator(ctx, fetch, nil)
+}
@@ -0,0 +1,25 @@
+//go:build go1.23
+
+package tistoryAPI
+
+import "iter"
+
+// All range-over-func 용 iter.Seq2 (Go 1.23 이상)
+// 에러가 발생하면 zero 값과 함께 에러를 한 번 넘기고 순회를 끝낸다.
+//
+//	for post, err := range s.PostIter(ctx, blogName).All() {
+//		if err != nil { ... }
+//	}
+func (it *Iterator[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for it.Next() {
+			if !yield(it.Item(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+//go:build go1.23
+
+package tistoryAPI
+
+import (
+	"context"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"github.com/fineroot1253/tistoryAPI/tistorytest"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func TestIterator_All(t *testing.T) {
+	serv, srv := newTestService(t)
+	for i := 0; i < 12; i++ {
+		srv.AddPost(model.PostData{BlogName: tistorytest.BlogName, Title: "글 " + strconv.Itoa(i)})
+	}
+
+	var titles []string
+	for post, err := range serv.PostIter(context.Background(), tistorytest.BlogName).All() {
+		assert.NoError(t, err)
+		titles = append(titles, post.Title)
+	}
+	assert.Len(t, titles, 12)
+	assert.Equal(t, "글 11", titles[0])
+
+	var gotErr error
+	for _, err := range serv.PostIter(context.Background(), "unknown-blog").All() {
+		gotErr = err
+	}
+	assert.ErrorIs(t, gotErr, ErrBadRequest)
+}
@@ -0,0 +1,160 @@
+package tistoryAPI
+
+import (
+	"context"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"github.com/fineroot1253/tistoryAPI/tistorytest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingTransport path 로 들어간 요청 횟수를 센다. (기본값: 글 목록)
+type countingTransport struct {
+	path  string
+	count atomic.Int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := c.path
+	if path == "" {
+		path = "/apis/post/list"
+	}
+	if req.URL.Path == path {
+		c.count.Add(1)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestService_PostIter(t *testing.T) {
+	srv := tistorytest.NewServer()
+	defer srv.Close()
+
+	dev := srv.AddCategory("Dev", "")
+	life := srv.AddCategory("Life", "")
+	// 23 건 = 10 + 10 + 3, 짝수번째는 Dev 발행글, 홀수번째는 Life 비공개글
+	for i := 0; i < 23; i++ {
+		data := model.PostData{BlogName: tistorytest.BlogName, Title: "글 " + strconv.Itoa(i), Category: life, Visibility: model.Private}
+		if i%2 == 0 {
+			data.Category, data.Visibility = dev, model.Public
+		}
+		srv.AddPost(data)
+	}
+
+	tests := []struct {
+		name      string
+		opts      []PostIterOption
+		want      int
+		wantPages int32
+	}{
+		{name: "전체 글 순회:[success]", want: 23, wantPages: 3},
+		{name: "카테고리 필터:[success]", opts: []PostIterOption{WithCategoryFilter(dev)}, want: 12, wantPages: 3},
+		{name: "공개 여부 필터:[success]", opts: []PostIterOption{WithVisibilityFilter(model.Private)}, want: 11, wantPages: 3},
+		{name: "발행글 필터 (글 목록 코드 20):[success]", opts: []PostIterOption{WithCategoryFilter(dev), WithVisibilityFilter(model.Public)}, want: 12, wantPages: 3},
+		{name: "필터 조합:[success]", opts: []PostIterOption{WithCategoryFilter(life), WithVisibilityFilter(model.Public)}, want: 0, wantPages: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &countingTransport{}
+			serv, err := NewService(context.Background(), srv.UserData(),
+				WithBaseURL(srv.APIURL()), WithOAuthURL(srv.OAuthURL()), WithHTTPClient(&http.Client{Transport: transport}))
+			assert.NoError(t, err)
+
+			it := serv.PostIter(context.Background(), tistorytest.BlogName, tt.opts...)
+			got := 0
+			for it.Next() {
+				got++
+			}
+			assert.NoError(t, it.Err())
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPages, transport.count.Load())
+		})
+	}
+}
+
+func TestService_PostIter_Lazy(t *testing.T) {
+	srv := tistorytest.NewServer()
+	defer srv.Close()
+	for i := 0; i < 15; i++ {
+		srv.AddPost(model.PostData{BlogName: tistorytest.BlogName, Title: "글 " + strconv.Itoa(i)})
+	}
+
+	transport := &countingTransport{}
+	serv, err := NewService(context.Background(), srv.UserData(),
+		WithBaseURL(srv.APIURL()), WithOAuthURL(srv.OAuthURL()), WithHTTPClient(&http.Client{Transport: transport}))
+	assert.NoError(t, err)
+
+	it := serv.PostIter(context.Background(), tistorytest.BlogName)
+	assert.Equal(t, int32(0), transport.count.Load())
+	for i := 0; i < 10; i++ {
+		assert.True(t, it.Next())
+	}
+	assert.Equal(t, int32(1), transport.count.Load())
+	assert.True(t, it.Next())
+	assert.Equal(t, int32(2), transport.count.Load())
+}
+
+func TestService_PostIter_Error(t *testing.T) {
+	serv, _ := newTestService(t)
+
+	it := serv.PostIter(context.Background(), "unknown-blog")
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), ErrBadRequest)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	it = serv.PostIter(ctx, tistorytest.BlogName)
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+}
+
+func TestService_NewestComments(t *testing.T) {
+	srv := tistorytest.NewServer()
+	defer srv.Close()
+
+	// 1분 간격으로 25 개 댓글, i 번째 댓글 작성 시각은 base + i분
+	base := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := base
+	srv.Now = func() time.Time { return now }
+	postId := srv.AddPost(model.PostData{BlogName: tistorytest.BlogName, Title: "글"})
+	for i := 0; i < 25; i++ {
+		now = base.Add(time.Duration(i) * time.Minute)
+		srv.AddComment(model.CommentData{BlogName: tistorytest.BlogName, PostId: postId, Content: "댓글 " + strconv.Itoa(i)}, "name")
+	}
+
+	tests := []struct {
+		name      string
+		since     time.Time
+		want      int
+		wantFirst string
+		wantPages int32
+	}{
+		{name: "전체 댓글:[success]", want: 25, wantFirst: "댓글 24", wantPages: 3},
+		{name: "since 이후 댓글만:[success]", since: base.Add(4 * time.Minute), want: 20, wantFirst: "댓글 24", wantPages: 3},
+		{name: "첫 페이지에서 멈춤:[success]", since: base.Add(20 * time.Minute), want: 4, wantFirst: "댓글 24", wantPages: 1},
+		{name: "새 댓글 없음:[success]", since: base.Add(24 * time.Minute), want: 0, wantPages: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &countingTransport{path: "/apis/comment/newest"}
+			serv, err := NewService(context.Background(), srv.UserData(),
+				WithBaseURL(srv.APIURL()), WithOAuthURL(srv.OAuthURL()), WithHTTPClient(&http.Client{Transport: transport}))
+			assert.NoError(t, err)
+
+			var got []model.CommentNewestItemData
+			it := serv.NewestComments(context.Background(), tistorytest.BlogName, tt.since)
+			for it.Next() {
+				got = append(got, it.Item())
+			}
+			assert.NoError(t, it.Err())
+			assert.Len(t, got, tt.want)
+			if tt.want > 0 {
+				assert.Equal(t, tt.wantFirst, got[0].Comment)
+			}
+			assert.Equal(t, tt.wantPages, transport.count.Load())
+		})
+	}
+}
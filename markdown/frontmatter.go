@@ -0,0 +1,280 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"gopkg.in/yaml.v3"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidFrontMatter front matter 를 해석할 수 없음 (닫는 구분자 없음, 문법 오류, 값 형식 오류)
+var ErrInvalidFrontMatter = errors.New("markdown: invalid front matter")
+
+// FrontMatter 문서 맨 앞의 메타데이터
+// '---' 로 감싸면 YAML, '+++' 로 감싸면 TOML 로 읽는다. 모르는 키는 무시한다.
+//
+//	---
+//	title: 제목
+//	tags: [go, tistory]
+//	category: Dev/Go
+//	visibility: public
+//	published: 2024-01-02 09:00:00
+//	---
+type FrontMatter struct {
+	// Title 글 제목 (title)
+	Title string
+
+	// Tags 태그 목록 (tags, 목록 또는 ',' 로 구분한 문자열)
+	Tags []string
+
+	// Category 카테고리 이름 또는 '/' 로 구분한 경로 (category)
+	Category string
+
+	// Visibility 공개 여부 (visibility, public/protected/private 또는 0/1/3)
+	Visibility model.Visibility
+
+	// Published 배포 시간 (published, 시간대가 없으면 KST, 비어있으면 zero)
+	Published time.Time
+
+	// Slug 문자 주소 (slug)
+	Slug string
+
+	// Password 보호글 비밀번호 (password)
+	Password string
+
+	// PostId 수정할 글 번호 (postId, 비어있으면 새 글)
+	PostId string
+}
+
+// ParseFrontMatter 문서 맨 앞의 front matter 를 해석하고 나머지 본문을 반환한다.
+// front matter 가 없으면 빈 FrontMatter 와 source 를 그대로 반환한다.
+func ParseFrontMatter(source []byte) (FrontMatter, []byte, error) {
+	raw, body, format, ok := splitFrontMatter(source)
+	if !ok {
+		return FrontMatter{}, source, nil
+	}
+	if raw == nil {
+		return FrontMatter{}, nil, fmt.Errorf("%w: missing closing %q", ErrInvalidFrontMatter, format)
+	}
+
+	var values map[string]any
+	var err error
+	if format == "---" {
+		values, err = decodeYAML(raw)
+	} else {
+		values, err = decodeTOML(raw)
+	}
+	if err != nil {
+		return FrontMatter{}, nil, fmt.Errorf("%w: %v", ErrInvalidFrontMatter, err)
+	}
+
+	fm, err := newFrontMatter(values)
+	if err != nil {
+		return FrontMatter{}, nil, err
+	}
+	return fm, body, nil
+}
+
+// splitFrontMatter 첫 줄이 '---' 또는 '+++' 이면 같은 구분자 줄까지를 front matter 로 자른다.
+// 여는 구분자만 있고 닫는 구분자가 없으면 raw 가 nil 이다.
+func splitFrontMatter(source []byte) (raw, body []byte, format string, ok bool) {
+	source = bytes.TrimPrefix(source, []byte("\ufeff"))
+	first, rest, _ := cutLine(source)
+	format = string(bytes.TrimRight(first, " \t"))
+	if format != "---" && format != "+++" {
+		return nil, nil, "", false
+	}
+
+	for offset := 0; offset < len(rest); {
+		line, next, _ := cutLine(rest[offset:])
+		if string(bytes.TrimRight(line, " \t")) == format {
+			return rest[:offset], next, format, true
+		}
+		offset = len(rest) - len(next)
+	}
+	return nil, nil, format, true
+}
+
+// cutLine 첫 줄(줄바꿈 제외)과 나머지로 자른다. (\r\n 도 처리)
+func cutLine(b []byte) (line, rest []byte, found bool) {
+	line, rest, found = bytes.Cut(b, []byte("\n"))
+	return bytes.TrimSuffix(line, []byte("\r")), rest, found
+}
+
+func decodeYAML(raw []byte) (map[string]any, error) {
+	var nodes map[string]yaml.Node
+	if err := yaml.Unmarshal(raw, &nodes); err != nil {
+		return nil, err
+	}
+	values := make(map[string]any, len(nodes))
+	for key, node := range nodes {
+		// 시간은 yaml 이 UTC 로 해석하지 않게 문자열 그대로 받아서 직접 해석한다.
+		if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!timestamp" {
+			values[key] = node.Value
+			continue
+		}
+		var value any
+		if err := node.Decode(&value); err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+func decodeTOML(raw []byte) (map[string]any, error) {
+	values := map[string]any{}
+	if _, err := toml.Decode(string(raw), &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func newFrontMatter(values map[string]any) (FrontMatter, error) {
+	fm := FrontMatter{}
+	var err error
+	field := func(key string, parse func(value any) error) {
+		value, ok := values[key]
+		if !ok || value == nil || err != nil {
+			return
+		}
+		if parseErr := parse(value); parseErr != nil {
+			err = fmt.Errorf("%w: %s: %v", ErrInvalidFrontMatter, key, parseErr)
+		}
+	}
+	str := func(dst *string) func(value any) error {
+		return func(value any) (err error) {
+			*dst, err = scalarString(value)
+			return err
+		}
+	}
+
+	field("title", str(&fm.Title))
+	field("category", str(&fm.Category))
+	field("slug", str(&fm.Slug))
+	field("password", str(&fm.Password))
+	field("postId", str(&fm.PostId))
+	field("tags", func(value any) (err error) {
+		fm.Tags, err = parseTags(value)
+		return err
+	})
+	field("visibility", func(value any) (err error) {
+		fm.Visibility, err = parseVisibility(value)
+		return err
+	})
+	field("published", func(value any) (err error) {
+		fm.Published, err = parsePublished(value)
+		return err
+	})
+	return fm, err
+}
+
+// scalarString 문자열, 숫자, 불리언을 문자열로 바꾼다. (postId: 123, title: 1.5 처럼 따옴표 없이 쓴 경우)
+func scalarString(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+			return strconv.FormatInt(int64(v), 10), nil
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", fmt.Errorf("unexpected %T", value)
+}
+
+func parseTags(value any) ([]string, error) {
+	var tags []string
+	switch v := value.(type) {
+	case []any:
+		for _, item := range v {
+			tag, err := scalarString(item)
+			if err != nil {
+				return nil, err
+			}
+			tags = append(tags, tag)
+		}
+	default:
+		s, err := scalarString(v)
+		if err != nil {
+			return nil, err
+		}
+		tags = strings.Split(s, ",")
+	}
+
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			result = append(result, tag)
+		}
+	}
+	return result, nil
+}
+
+func parseVisibility(value any) (model.Visibility, error) {
+	s, err := scalarString(value)
+	if err != nil {
+		return "", err
+	}
+	switch strings.ToLower(s) {
+	case "public":
+		return model.Public, nil
+	case "protected":
+		return model.Protected, nil
+	case "private":
+		return model.Private, nil
+	}
+	return model.ParseVisibility(s)
+}
+
+// publishedLayouts 시간대가 없는 형식은 KST 로 해석한다.
+var publishedLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	model.TistoryDateLayout,
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+func parsePublished(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		// TOML 의 시간대 없는 날짜/시간
+		switch v.Location().String() {
+		case "datetime-local", "date-local":
+			return time.Date(v.Year(), v.Month(), v.Day(), v.Hour(), v.Minute(), v.Second(), v.Nanosecond(), model.KST), nil
+		}
+		return v, nil
+	case string:
+		for _, layout := range publishedLayouts {
+			if t, err := time.ParseInLocation(layout, strings.TrimSpace(v), model.KST); err == nil {
+				return t, nil
+			}
+		}
+	}
+
+	// TIMESTAMP
+	s, err := scalarString(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := model.ParseTistoryTime(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Time, nil
+}
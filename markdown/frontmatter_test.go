@@ -0,0 +1,117 @@
+package markdown
+
+import (
+	"github.com/fineroot1253/tistoryAPI/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseFrontMatter(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		want     FrontMatter
+		wantBody string
+		wantErr  error
+	}{
+		{
+			name: "YAML:[success]",
+			source: "---\ntitle: 제목\ntags: [go, \" tistory \"]\ncategory: Dev/Go\nvisibility: public\n" +
+				"published: 2024-01-02 09:00:00\nslug: hello\npassword: ''\npostId: 123\nunknown: x\n---\n본문\n",
+			want: FrontMatter{
+				Title: "제목", Tags: []string{"go", "tistory"}, Category: "Dev/Go", Visibility: model.Public,
+				Published: time.Date(2024, 1, 2, 9, 0, 0, 0, model.KST), Slug: "hello", PostId: "123",
+			},
+			wantBody: "본문\n",
+		},
+		{
+			name:     "TOML:[success]",
+			source:   "+++\r\ntitle = \"제목\"\r\ntags = \"go, tistory\"\r\nvisibility = 1\r\npassword = \"pw\"\r\npublished = 2024-01-02T09:00:00\r\npostId = 7\r\n+++\r\n본문",
+			want:     FrontMatter{Title: "제목", Tags: []string{"go", "tistory"}, Visibility: model.Protected, Password: "pw", Published: time.Date(2024, 1, 2, 9, 0, 0, 0, model.KST), PostId: "7"},
+			wantBody: "본문",
+		},
+		{
+			name:     "시간대, TIMESTAMP:[success]",
+			source:   "---\npublished: 2024-01-02T00:00:00Z\n---\n",
+			want:     FrontMatter{Published: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+			wantBody: "",
+		},
+		{
+			name:     "TIMESTAMP:[success]",
+			source:   "+++\npublished = 1704153600\n+++\n",
+			want:     FrontMatter{Published: time.Unix(1704153600, 0)},
+			wantBody: "",
+		},
+		{
+			name:     "따옴표 없는 숫자, 불리언:[success]",
+			source:   "---\ntitle: 1.5\nslug: 2024.1\ncategory: true\ntags: [1.0, 2.5, false]\npostId: 12\n---\n",
+			want:     FrontMatter{Title: "1.5", Slug: "2024.1", Category: "true", Tags: []string{"1", "2.5", "false"}, PostId: "12"},
+			wantBody: "",
+		},
+		{
+			name:     "TOML 실수, 불리언:[success]",
+			source:   "+++\ntitle = 3.25\nslug = true\n+++\n",
+			want:     FrontMatter{Title: "3.25", Slug: "true"},
+			wantBody: "",
+		},
+		{
+			name:     "front matter 없음:[success]",
+			source:   "# 제목\n---\n",
+			wantBody: "# 제목\n---\n",
+		},
+		{
+			name:    "닫는 구분자 없음:[failure]",
+			source:  "---\ntitle: 제목\n",
+			wantErr: ErrInvalidFrontMatter,
+		},
+		{
+			name:    "문법 오류:[failure]",
+			source:  "+++\ntitle = \n+++\n",
+			wantErr: ErrInvalidFrontMatter,
+		},
+		{
+			name:    "잘못된 공개 여부:[failure]",
+			source:  "---\nvisibility: hidden\n---\n",
+			wantErr: ErrInvalidFrontMatter,
+		},
+		{
+			name:    "잘못된 시간:[failure]",
+			source:  "---\npublished: tomorrow\n---\n",
+			wantErr: ErrInvalidFrontMatter,
+		},
+		{
+			name:    "목록 제목:[failure]",
+			source:  "---\ntitle: [a, b]\n---\n",
+			wantErr: ErrInvalidFrontMatter,
+		},
+		{
+			name:    "잘못된 태그:[failure]",
+			source:  "---\ntags: {a: b}\n---\n",
+			wantErr: ErrInvalidFrontMatter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, body, err := ParseFrontMatter([]byte(tt.source))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tt.want.Published.Equal(got.Published), got.Published)
+			tt.want.Published, got.Published = time.Time{}, time.Time{}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantBody, string(body))
+		})
+	}
+}
+
+func TestConvert_FrontMatter(t *testing.T) {
+	got, err := Convert([]byte("---\ntitle: 앞 제목\n---\n# 본문 제목\n\n본문\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "앞 제목", got.Title)
+	assert.Equal(t, "앞 제목", got.FrontMatter.Title)
+	assert.Contains(t, got.HTML, ">본문 제목</h1>\n<p>본문</p>\n")
+	assert.NotContains(t, got.HTML, "title:")
+}
@@ -0,0 +1,166 @@
+// Package markdown Markdown 을 Tistory 본문용 HTML 로 바꾸는 변환기
+// CommonMark + GFM (표, 취소선, 체크리스트, 자동 링크) 과 각주를 지원하며,
+// 코드 블록은 <pre><code class="language-go"> 처럼 언어 클래스를 붙여 Tistory 스킨의 하이라이터가 인식하게 한다.
+// Markdown 안의 HTML 은 그대로 통과시킨다. (직접 쓴 문서를 올리는 용도이므로)
+// 문서 맨 앞의 YAML('---'), TOML('+++') front matter 는 FrontMatter 로 읽는다.
+package markdown
+
+import (
+	"bytes"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// Document 변환 결과
+// Title		front matter 의 title, 없으면 본문 맨 앞의 '# 제목' (본문에서는 빠진다)
+// HTML			본문 HTML
+// FrontMatter	front matter (없으면 빈 값)
+type Document struct {
+	// Title front matter 의 title, 없으면 본문 맨 앞의 '# 제목' (본문에서는 빠진다)
+	Title string
+
+	// HTML 본문 HTML
+	HTML string
+
+	// FrontMatter front matter (없으면 빈 값)
+	FrontMatter FrontMatter
+}
+
+// Option Converter 설정 옵션
+type Option func(*config)
+
+type config struct {
+	codeClass func(language string) string
+	hardWraps bool
+}
+
+// WithCodeClass 코드 블록 언어로 <code> 의 class 를 만드는 함수 지정
+// 기본값은 "language-" + 언어 (highlight.js, Prism), 빈 문자열을 반환하면 class 를 붙이지 않는다.
+// 언어가 없는 코드 블록에는 호출하지 않는다.
+func WithCodeClass(fn func(language string) string) Option {
+	return func(c *config) {
+		c.codeClass = fn
+	}
+}
+
+// WithHardWraps 문단 안의 줄바꿈을 <br> 로 바꾼다. (Tistory 에디터처럼 보이게)
+func WithHardWraps() Option {
+	return func(c *config) {
+		c.hardWraps = true
+	}
+}
+
+// Converter Markdown => HTML 변환기 (여러 goroutine 에서 같이 써도 된다)
+type Converter struct {
+	md goldmark.Markdown
+}
+
+// New 변환기 생성
+func New(opts ...Option) *Converter {
+	c := config{codeClass: func(language string) string { return "language-" + language }}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	rendererOpts := []renderer.Option{
+		html.WithUnsafe(),
+		renderer.WithNodeRenderers(util.Prioritized(&codeBlockRenderer{codeClass: c.codeClass}, 100)),
+	}
+	if c.hardWraps {
+		rendererOpts = append(rendererOpts, html.WithHardWraps())
+	}
+
+	return &Converter{md: goldmark.New(
+		goldmark.WithExtensions(extension.GFM, extension.Footnote),
+		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+		goldmark.WithRendererOptions(rendererOpts...),
+	)}
+}
+
+// Convert Markdown 변환
+// front matter 에 title 이 없고 본문이 '# 제목' 으로 시작하면 Title 로 꺼내고 본문에서는 뺀다.
+func (c *Converter) Convert(source []byte) (Document, error) {
+	frontMatter, source, err := ParseFrontMatter(source)
+	if err != nil {
+		return Document{}, err
+	}
+	doc := c.md.Parser().Parse(text.NewReader(source))
+
+	result := Document{Title: frontMatter.Title, FrontMatter: frontMatter}
+	if heading, ok := doc.FirstChild().(*ast.Heading); ok && heading.Level == 1 && result.Title == "" {
+		result.Title = plainText(heading, source)
+		doc.RemoveChild(doc, heading)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := c.md.Renderer().Render(buf, source, doc); err != nil {
+		return Document{}, err
+	}
+	result.HTML = buf.String()
+	return result, nil
+}
+
+// Convert 기본 설정 변환기로 변환
+func Convert(source []byte, opts ...Option) (Document, error) {
+	return New(opts...).Convert(source)
+}
+
+// plainText 노드 안의 글자만 이어 붙인다. (강조, 링크등 태그는 버린다)
+func plainText(n ast.Node, source []byte) string {
+	buf := &bytes.Buffer{}
+	_ = ast.Walk(n, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch t := node.(type) {
+		case *ast.Text:
+			buf.Write(t.Segment.Value(source))
+			if t.SoftLineBreak() || t.HardLineBreak() {
+				buf.WriteByte(' ')
+			}
+		case *ast.String:
+			buf.Write(t.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return string(bytes.TrimSpace(buf.Bytes()))
+}
+
+// codeBlockRenderer 코드 블록 class 를 codeClass 로 정하는 렌더러
+// goldmark 기본 렌더러보다 우선순위가 높아서 코드 블록만 대신 그린다.
+type codeBlockRenderer struct {
+	codeClass func(language string) string
+}
+
+func (r *codeBlockRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, r.renderFencedCodeBlock)
+}
+
+func (r *codeBlockRenderer) renderFencedCodeBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		_, _ = w.WriteString("</code></pre>\n")
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*ast.FencedCodeBlock)
+	_, _ = w.WriteString("<pre><code")
+	if language := n.Language(source); language != nil && r.codeClass != nil {
+		if class := r.codeClass(string(language)); class != "" {
+			_, _ = w.WriteString(` class="`)
+			_, _ = w.Write(util.EscapeHTML([]byte(class)))
+			_ = w.WriteByte('"')
+		}
+	}
+	_ = w.WriteByte('>')
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		html.DefaultWriter.RawWrite(w, line.Value(source))
+	}
+	return ast.WalkContinue, nil
+}
@@ -0,0 +1,81 @@
+package markdown
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		opts      []Option
+		wantTitle string
+		contains  []string
+		excludes  []string
+	}{
+		{
+			name:      "제목 추출:[success]",
+			source:    "# 안녕 *Go*\n\n본문입니다.\n",
+			wantTitle: "안녕 Go",
+			contains:  []string{"<p>본문입니다.</p>"},
+			excludes:  []string{"<h1"},
+		},
+		{
+			name:     "맨 앞이 아닌 제목은 본문:[success]",
+			source:   "본문\n\n# Title\n",
+			contains: []string{`<h1 id="title">Title</h1>`},
+		},
+		{
+			name:   "GFM 표, 취소선, 체크리스트:[success]",
+			source: "| a | b |\n|---|---|\n| 1 | 2 |\n\n~~old~~\n\n- [x] done\n",
+			contains: []string{
+				"<table>", "<th>a</th>", "<td>2</td>",
+				"<del>old</del>",
+				`<input checked="" disabled="" type="checkbox"`,
+			},
+		},
+		{
+			name:     "코드 블록 언어 클래스:[success]",
+			source:   "```go\nif a < b && c {\n}\n```\n\n```\nplain\n```\n",
+			contains: []string{"<pre><code class=\"language-go\">if a &lt; b &amp;&amp; c {\n}\n</code></pre>", "<pre><code>plain\n</code></pre>"},
+		},
+		{
+			name:     "코드 블록 클래스 변경:[success]",
+			source:   "```go\nx := 1\n```\n",
+			opts:     []Option{WithCodeClass(func(language string) string { return "hljs " + language })},
+			contains: []string{`<pre><code class="hljs go">`},
+		},
+		{
+			name:     "코드 블록 클래스 없음:[success]",
+			source:   "```go\nx := 1\n```\n",
+			opts:     []Option{WithCodeClass(func(string) string { return "" })},
+			contains: []string{"<pre><code>x := 1"},
+		},
+		{
+			name:     "각주:[success]",
+			source:   "본문[^1]\n\n[^1]: 각주 내용\n",
+			contains: []string{`<sup id="fnref:1">`, `class="footnotes"`, "각주 내용"},
+		},
+		{
+			name:     "HTML 통과, 줄바꿈:[success]",
+			source:   "<div class=\"box\">html</div>\n\n첫 줄\n둘째 줄\n",
+			opts:     []Option{WithHardWraps()},
+			contains: []string{`<div class="box">html</div>`, "첫 줄<br>"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Convert([]byte(tt.source), tt.opts...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTitle, got.Title)
+			for _, want := range tt.contains {
+				assert.Contains(t, got.HTML, want)
+			}
+			for _, exclude := range tt.excludes {
+				assert.False(t, strings.Contains(got.HTML, exclude), exclude)
+			}
+		})
+	}
+}
@@ -18,7 +18,7 @@ type BlogItemListData struct {
 	Nickname                 string             `json:"nickname"`
 	Title                    string             `json:"title"`
 	Description              string             `json:"description"`
-	Default                  string             `json:"default"`
+	Default                  YN                 `json:"default"`
 	BlogIconUrl              string             `json:"blogIconUrl"`
 	FaviconUrl               string             `json:"faviconUrl"`
 	ProfileThumbnailImageUrl string             `json:"profileThumbnailImageUrl"`
@@ -29,9 +29,9 @@ type BlogItemListData struct {
 }
 
 type BlogStatisticsData struct {
-	Post       string `json:"post"`
-	Comment    string `json:"comment"`
-	Trackback  string `json:"trackback"`
-	Guestbook  string `json:"guestbook"`
-	Invitation string `json:"invitation"`
+	Post       FlexInt `json:"post"`
+	Comment    FlexInt `json:"comment"`
+	Trackback  FlexInt `json:"trackback"`
+	Guestbook  FlexInt `json:"guestbook"`
+	Invitation FlexInt `json:"invitation"`
 }
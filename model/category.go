@@ -12,9 +12,9 @@ type CategoryItem struct {
 }
 
 type CategoryData struct {
-	Id      string `json:"id"`
-	Name    string `json:"name"`
-	Parent  string `json:"parent"`
-	Label   string `json:"label"`
-	Entries string `json:"entries"`
+	Id      string  `json:"id"`
+	Name    string  `json:"name"`
+	Parent  string  `json:"parent"`
+	Label   string  `json:"label"`
+	Entries FlexInt `json:"entries"`
 }
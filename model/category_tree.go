@@ -0,0 +1,155 @@
+package model
+
+import (
+	"strings"
+)
+
+// CategoryNode 카테고리 트리의 노드
+// CategoryData	카테고리 목록 API 의 원본 데이터 embed
+// ParentNode	부모 카테고리 (최상위면 nil)
+// Children		하위 카테고리 목록 (응답 순서)
+// Path			최상위부터 이름을 '/' 로 이은 경로 (ex: "Dev/Go")
+// TotalEntries	하위 카테고리를 포함한 글 수
+type CategoryNode struct {
+	// CategoryData 카테고리 목록 API 의 원본 데이터 embed
+	CategoryData
+
+	// ParentNode 부모 카테고리 (최상위면 nil)
+	ParentNode *CategoryNode
+
+	// Children 하위 카테고리 목록 (응답 순서)
+	Children []*CategoryNode
+
+	// Path 최상위부터 이름을 '/' 로 이은 경로 (ex: "Dev/Go")
+	Path string
+
+	// TotalEntries 하위 카테고리를 포함한 글 수
+	TotalEntries int
+}
+
+// CategoryTree 평평한 카테고리 목록을 Parent 기준으로 엮은 트리
+type CategoryTree struct {
+	// Roots 최상위 카테고리 목록 (응답 순서)
+	Roots []*CategoryNode
+
+	byId   map[string]*CategoryNode
+	byPath map[string]*CategoryNode
+}
+
+// NewCategoryTree 카테고리 목록 응답으로 트리 생성
+// Parent 가 비어있거나, "0" 이거나, 목록에 없는 ID 면 최상위 카테고리로 본다.
+// Parent 가 순환하면 (1 -> 2 -> 1) 응답 순서상 순환을 닫는 카테고리를 최상위로 올려 빠지는 카테고리가 없게 한다.
+func NewCategoryTree(result CategoryResult) *CategoryTree {
+	tree := &CategoryTree{byId: map[string]*CategoryNode{}, byPath: map[string]*CategoryNode{}}
+
+	categories := result.Item.Categories
+	nodes := make([]*CategoryNode, len(categories))
+	for i, category := range categories {
+		nodes[i] = &CategoryNode{CategoryData: category}
+		tree.byId[category.Id] = nodes[i]
+	}
+	for _, node := range nodes {
+		parent, ok := tree.byId[node.Parent]
+		if !ok || parent.hasAncestor(node) {
+			tree.Roots = append(tree.Roots, node)
+			continue
+		}
+		node.ParentNode = parent
+		parent.Children = append(parent.Children, node)
+	}
+
+	for _, root := range tree.Roots {
+		tree.index(root, "")
+	}
+	return tree
+}
+
+// hasAncestor 자신 또는 상위 카테고리 중에 node 가 있는지 (있으면 node 를 자식으로 붙일 때 순환이 생긴다)
+func (n *CategoryNode) hasAncestor(node *CategoryNode) bool {
+	for ; n != nil; n = n.ParentNode {
+		if n == node {
+			return true
+		}
+	}
+	return false
+}
+
+// index 경로를 채우고 하위 글 수를 합산한다.
+func (t *CategoryTree) index(node *CategoryNode, parentPath string) int {
+	node.Path = node.Name
+	if parentPath != "" {
+		node.Path = parentPath + "/" + node.Name
+	}
+	t.byPath[node.Path] = node
+
+	node.TotalEntries = node.Entries.Int()
+	for _, child := range node.Children {
+		node.TotalEntries += t.index(child, node.Path)
+	}
+	return node.TotalEntries
+}
+
+// FindByID ID 로 카테고리 찾기
+func (t *CategoryTree) FindByID(id string) (*CategoryNode, bool) {
+	node, ok := t.byId[id]
+	return node, ok
+}
+
+// FindByPath "Dev/Go" 같은 경로로 카테고리 찾기
+// 각 이름의 앞뒤 공백과 경로 앞뒤의 '/' 는 무시한다.
+func (t *CategoryTree) FindByPath(path string) (*CategoryNode, bool) {
+	names := strings.Split(strings.Trim(path, "/"), "/")
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+	}
+	node, ok := t.byPath[strings.Join(names, "/")]
+	return node, ok
+}
+
+// FindByName 이름이 같은 카테고리 목록 (트리 순서, 앞뒤 공백은 무시한다)
+// 다른 부모 아래에 같은 이름이 있을 수 있으므로 여러 개를 반환할 수 있다.
+func (t *CategoryTree) FindByName(name string) []*CategoryNode {
+	name = strings.TrimSpace(name)
+	var nodes []*CategoryNode
+	_ = t.Walk(func(node *CategoryNode, depth int) error {
+		if node.Name == name {
+			nodes = append(nodes, node)
+		}
+		return nil
+	})
+	return nodes
+}
+
+// Children 하위 카테고리 목록 (id 가 빈 문자열이면 최상위 카테고리 목록)
+func (t *CategoryTree) Children(id string) []*CategoryNode {
+	if id == "" {
+		return t.Roots
+	}
+	if node, ok := t.byId[id]; ok {
+		return node.Children
+	}
+	return nil
+}
+
+// Walk 최상위부터 깊이 우선(부모 먼저)으로 모든 카테고리를 방문한다.
+// depth 는 최상위가 0 이며, fn 이 에러를 반환하면 순회를 멈추고 그 에러를 반환한다.
+func (t *CategoryTree) Walk(fn func(node *CategoryNode, depth int) error) error {
+	for _, root := range t.Roots {
+		if err := walk(root, 0, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walk(node *CategoryNode, depth int, fn func(node *CategoryNode, depth int) error) error {
+	if err := fn(node, depth); err != nil {
+		return err
+	}
+	for _, child := range node.Children {
+		if err := walk(child, depth+1, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package model
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestCategoryTree(t *testing.T) {
+	result := CategoryResult{Item: CategoryItem{Categories: []CategoryData{
+		{Id: "1", Name: "Dev", Entries: 1},
+		{Id: "2", Name: "Go", Parent: "1", Label: "Dev/Go", Entries: 3},
+		{Id: "3", Name: "Generics", Parent: "2", Label: "Dev/Go/Generics", Entries: 2},
+		{Id: "4", Name: "Rust", Parent: "1", Label: "Dev/Rust", Entries: 4},
+		{Id: "5", Name: "Life", Parent: "0", Entries: 5},
+	}}}
+	tree := NewCategoryTree(result)
+
+	t.Run("FindByID:[success]", func(t *testing.T) {
+		node, ok := tree.FindByID("3")
+		assert.True(t, ok)
+		assert.Equal(t, "Dev/Go/Generics", node.Path)
+		assert.Equal(t, "2", node.ParentNode.Id)
+
+		_, ok = tree.FindByID("404")
+		assert.False(t, ok)
+	})
+
+	t.Run("FindByPath:[success]", func(t *testing.T) {
+		tests := []struct {
+			path string
+			want string
+		}{
+			{path: "Dev", want: "1"},
+			{path: "Dev/Go", want: "2"},
+			{path: " Dev / Go / Generics ", want: "3"},
+			{path: "/Life/", want: "5"},
+		}
+		for _, tt := range tests {
+			node, ok := tree.FindByPath(tt.path)
+			assert.True(t, ok, tt.path)
+			assert.Equal(t, tt.want, node.Id, tt.path)
+		}
+
+		_, ok := tree.FindByPath("Go")
+		assert.False(t, ok)
+	})
+
+	t.Run("FindByName:[success]", func(t *testing.T) {
+		nodes := tree.FindByName(" Go ")
+		assert.Len(t, nodes, 1)
+		assert.Equal(t, "2", nodes[0].Id)
+
+		assert.Empty(t, tree.FindByName("Python"))
+
+		dup := NewCategoryTree(CategoryResult{Item: CategoryItem{Categories: []CategoryData{
+			{Id: "1", Name: "Dev"},
+			{Id: "2", Name: "Notes", Parent: "1"},
+			{Id: "3", Name: "Notes"},
+		}}})
+		nodes = dup.FindByName("Notes")
+		assert.Len(t, nodes, 2)
+		assert.Equal(t, "Dev/Notes", nodes[0].Path)
+		assert.Equal(t, "Notes", nodes[1].Path)
+	})
+
+	t.Run("Children:[success]", func(t *testing.T) {
+		assert.Len(t, tree.Children(""), 2)
+		assert.Len(t, tree.Children("1"), 2)
+		assert.Empty(t, tree.Children("3"))
+		assert.Nil(t, tree.Children("404"))
+	})
+
+	t.Run("TotalEntries:[success]", func(t *testing.T) {
+		dev, _ := tree.FindByID("1")
+		assert.Equal(t, 10, dev.TotalEntries)
+		goNode, _ := tree.FindByID("2")
+		assert.Equal(t, 5, goNode.TotalEntries)
+	})
+
+	t.Run("Walk:[success]", func(t *testing.T) {
+		var visited []string
+		err := tree.Walk(func(node *CategoryNode, depth int) error {
+			visited = append(visited, strings.Repeat("-", depth)+node.Name)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Dev", "-Go", "--Generics", "-Rust", "Life"}, visited)
+
+		stop := errors.New("stop")
+		count := 0
+		err = tree.Walk(func(node *CategoryNode, depth int) error {
+			count++
+			if node.Name == "Go" {
+				return stop
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 2, count)
+	})
+}
+
+func TestCategoryTree_Cycle(t *testing.T) {
+	// 1 -> 2 -> 3 -> 1 순환과 그 아래 카테고리 (4)
+	tree := NewCategoryTree(CategoryResult{Item: CategoryItem{Categories: []CategoryData{
+		{Id: "1", Name: "A", Parent: "3", Entries: 1},
+		{Id: "2", Name: "B", Parent: "1", Entries: 1},
+		{Id: "3", Name: "C", Parent: "2", Entries: 1},
+		{Id: "4", Name: "D", Parent: "2", Entries: 1},
+	}}})
+
+	var visited []string
+	assert.NoError(t, tree.Walk(func(node *CategoryNode, depth int) error {
+		visited = append(visited, strings.Repeat("-", depth)+node.Name)
+		return nil
+	}))
+	// 순환을 닫는 카테고리 (3) 가 최상위가 되고 빠지는 카테고리가 없다.
+	assert.Equal(t, []string{"C", "-A", "--B", "---D"}, visited)
+
+	node, ok := tree.FindByPath("C/A/B/D")
+	assert.True(t, ok)
+	assert.Equal(t, "4", node.Id)
+	root, _ := tree.FindByID("3")
+	assert.Equal(t, 4, root.TotalEntries)
+}
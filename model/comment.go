@@ -8,7 +8,7 @@ type CommentItemModel interface {
 // BlogName 블로그 명 (필수)
 // PostId	포스트 ID (필수)
 // ParentId	부모 댓글 Id (대댓글 인경우에 사용할 것)
-// Content	댓글 내용
+// Content	댓글 내용 (필수)
 // Secret	비밀 댓글 여부(0: 공개[default], 1: 비밀)
 type CommentData struct {
 	// BlogName 블로그 명 (필수)
@@ -20,11 +20,11 @@ type CommentData struct {
 	// ParentId	부모 댓글 Id (대댓글 인경우에 사용할 것)
 	ParentId string `json:"parent_id"`
 
-	// Content	댓글 내용
+	// Content	댓글 내용 (필수)
 	Content string `json:"content"`
 
 	// Secret	비밀 댓글 여부(0: 공개[default], 1: 비밀)
-	Secret string `json:"secret"`
+	Secret CommentSecrecy `json:"secret"`
 }
 
 // CommentUpdateData 댓글 쓰기용 DTO
@@ -103,7 +103,7 @@ type CommentListItem struct {
 	PostId string `json:"postId"`
 
 	// TotalCount	총 댓글 개수
-	TotalCount string `json:"totalCount"`
+	TotalCount FlexInt `json:"totalCount"`
 
 	// Comments 	CommentDataList 댓글 목록 데이터
 	Comments CommentDataList `json:"comments"`
@@ -113,7 +113,7 @@ type CommentListItem struct {
 // Comment [] CommentNewestItemData 최신 댓글 목록 아이템 리스트
 type CommentNewestDataList struct {
 	// Comment [] CommentNewestItemData 최신 댓글 목록 아이템 리스트
-	Comment []CommentNewestItemData
+	Comment []CommentNewestItemData `json:"comment"`
 }
 
 // CommentDataList
@@ -125,7 +125,7 @@ type CommentDataList struct {
 
 // CommentNewestItemData 최신 댓글 목록 아이템 타입
 // Id		댓글 ID
-// Date		댓글 작성시간 (시간[TIMESTAMP], milliseconds)
+// Date		댓글 작성시간 (시간[TIMESTAMP], 초 또는 milliseconds, 원문은 Date.Raw)
 // PostId	포스트 ID
 // Name		작성자 이름
 // Homepage	작성자 홈페이지 주소
@@ -135,8 +135,8 @@ type CommentNewestItemData struct {
 	// Id		댓글 ID
 	Id string `json:"id"`
 
-	// Date		댓글 작성시간 (시간[TIMESTAMP], milliseconds)
-	Date string `json:"date"`
+	// Date		댓글 작성시간 (시간[TIMESTAMP], 초 또는 milliseconds, 원문은 Date.Raw)
+	Date TistoryTime `json:"date"`
 
 	// PostId	포스트 ID
 	PostId string `json:"postId"`
@@ -151,12 +151,12 @@ type CommentNewestItemData struct {
 	Comment string `json:"comment"`
 
 	// Open		댓글 공개여부 (Y: 공개, N: 비공개)
-	Open string `json:"open"`
+	Open YN `json:"open"`
 }
 
 // CommentListItemData 댓글 목록 아이템 타입
 // Id			댓글 ID
-// Date			댓글 작성시간 ()
+// Date			댓글 작성시간 (시간[TIMESTAMP], 원문은 Date.Raw)
 // Name			작성자 이름
 // ParentId		대댓글 ID
 // Homepage		작성자 홈페이지 주소
@@ -167,8 +167,8 @@ type CommentListItemData struct {
 	// Id			댓글 ID
 	Id string `json:"id"`
 
-	// Date			댓글 작성시간 ()
-	Date string `json:"date"`
+	// Date			댓글 작성시간 (시간[TIMESTAMP], 원문은 Date.Raw)
+	Date TistoryTime `json:"date"`
 
 	// Name			작성자 이름
 	Name string `json:"name"`
@@ -186,5 +186,5 @@ type CommentListItemData struct {
 	Comment string `json:"comment"`
 
 	// Open			댓글 공개여부 (Y: 공개, N: 비공개)
-	Open string `json:"open"`
+	Open YN `json:"open"`
 }
@@ -0,0 +1,169 @@
+package model
+
+import (
+	"sort"
+)
+
+// CommentNode 댓글 스레드의 노드
+// CommentListItemData	댓글 목록 API 의 원본 데이터 embed
+// ParentNode			부모 댓글 (최상위 댓글이면 nil)
+// Children				답글 목록 (작성시간 순)
+// Depth				최상위 댓글이 0, 답글은 1 씩 증가
+type CommentNode struct {
+	// CommentListItemData 댓글 목록 API 의 원본 데이터 embed
+	CommentListItemData
+
+	// ParentNode 부모 댓글 (최상위 댓글이면 nil)
+	ParentNode *CommentNode
+
+	// Children 답글 목록 (작성시간 순)
+	Children []*CommentNode
+
+	// Depth 최상위 댓글이 0, 답글은 1 씩 증가
+	Depth int
+}
+
+// Pending 승인 대기 댓글 여부 (Visibility == "0")
+func (n *CommentNode) Pending() bool {
+	return n.Visibility == "0"
+}
+
+// Secret 비밀 댓글 여부 (Open == "N")
+func (n *CommentNode) Secret() bool {
+	return !bool(n.Open)
+}
+
+// CountPending 자신과 모든 답글 중 승인 대기 댓글 수
+func (n *CommentNode) CountPending() int {
+	return n.count((*CommentNode).Pending)
+}
+
+// CountSecret 자신과 모든 답글 중 비밀 댓글 수
+func (n *CommentNode) CountSecret() int {
+	return n.count((*CommentNode).Secret)
+}
+
+// Size 자신과 모든 답글 수
+func (n *CommentNode) Size() int {
+	return n.count(func(*CommentNode) bool { return true })
+}
+
+func (n *CommentNode) count(match func(*CommentNode) bool) int {
+	count := 0
+	if match(n) {
+		count++
+	}
+	for _, child := range n.Children {
+		count += child.count(match)
+	}
+	return count
+}
+
+// CommentThread 평평한 댓글 목록을 ParentId 기준으로 엮은 스레드 목록
+type CommentThread struct {
+	// Roots 최상위 댓글 목록 (작성시간 순), 최상위 댓글 하나가 스레드 하나다.
+	Roots []*CommentNode
+
+	byId map[string]*CommentNode
+}
+
+// BuildCommentThread 댓글 목록 응답으로 스레드 생성
+// ParentId 가 비어있거나, "0" 이거나, 목록에 없는 댓글(삭제등) 이면 최상위 댓글로 본다.
+// ParentId 가 순환하면 (1 -> 2 -> 1) 응답 순서상 순환을 닫는 댓글을 최상위로 올려 빠지는 댓글이 없게 한다.
+// 같은 부모의 답글은 작성시간 순으로, 같은 시간이면 응답 순서대로 정렬한다.
+func BuildCommentThread(item CommentListItem) *CommentThread {
+	thread := &CommentThread{byId: map[string]*CommentNode{}}
+
+	comments := item.Comments.Comment
+	nodes := make([]*CommentNode, len(comments))
+	for i, comment := range comments {
+		nodes[i] = &CommentNode{CommentListItemData: comment}
+		thread.byId[comment.Id] = nodes[i]
+	}
+	for _, node := range nodes {
+		parent, ok := thread.byId[node.ParentId]
+		if !ok || parent.hasAncestor(node) {
+			thread.Roots = append(thread.Roots, node)
+			continue
+		}
+		node.ParentNode = parent
+		parent.Children = append(parent.Children, node)
+	}
+
+	sortByDate(thread.Roots)
+	for _, root := range thread.Roots {
+		setDepth(root, 0)
+	}
+	return thread
+}
+
+// hasAncestor 자신 또는 상위 댓글 중에 node 가 있는지 (있으면 node 를 답글로 붙일 때 순환이 생긴다)
+func (n *CommentNode) hasAncestor(node *CommentNode) bool {
+	for ; n != nil; n = n.ParentNode {
+		if n == node {
+			return true
+		}
+	}
+	return false
+}
+
+func setDepth(node *CommentNode, depth int) {
+	node.Depth = depth
+	sortByDate(node.Children)
+	for _, child := range node.Children {
+		setDepth(child, depth+1)
+	}
+}
+
+func sortByDate(nodes []*CommentNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].Date.Before(nodes[j].Date.Time)
+	})
+}
+
+// Find ID 로 댓글 찾기
+func (t *CommentThread) Find(id string) (*CommentNode, bool) {
+	node, ok := t.byId[id]
+	return node, ok
+}
+
+// Walk 스레드 순서대로 깊이 우선(부모 먼저)으로 모든 댓글을 방문한다.
+// fn 이 에러를 반환하면 순회를 멈추고 그 에러를 반환한다.
+func (t *CommentThread) Walk(fn func(node *CommentNode) error) error {
+	for _, root := range t.Roots {
+		if err := walkComment(root, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkComment(node *CommentNode, fn func(node *CommentNode) error) error {
+	if err := fn(node); err != nil {
+		return err
+	}
+	for _, child := range node.Children {
+		if err := walkComment(child, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CountPending 전체 승인 대기 댓글 수
+func (t *CommentThread) CountPending() int {
+	count := 0
+	for _, root := range t.Roots {
+		count += root.CountPending()
+	}
+	return count
+}
+
+// CountSecret 전체 비밀 댓글 수
+func (t *CommentThread) CountSecret() int {
+	count := 0
+	for _, root := range t.Roots {
+		count += root.CountSecret()
+	}
+	return count
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildCommentThread(t *testing.T) {
+	at := func(minute int) TistoryTime {
+		return NewUnixTime(time.Date(2023, 1, 1, 0, minute, 0, 0, time.UTC))
+	}
+	item := CommentListItem{}
+	item.Comments.Comment = []CommentListItemData{
+		{Id: "3", Date: at(3), ParentId: "1", Visibility: "2", Open: true},
+		{Id: "1", Date: at(1), Visibility: "2", Open: true},
+		{Id: "2", Date: at(2), ParentId: "1", Visibility: "0", Open: false},
+		{Id: "4", Date: at(4), ParentId: "2", Visibility: "0", Open: true},
+		{Id: "5", Date: at(0), ParentId: "0", Visibility: "2", Open: false},
+		{Id: "6", Date: at(5), ParentId: "999", Visibility: "2", Open: true},
+	}
+	thread := BuildCommentThread(item)
+
+	var visited []string
+	assert.NoError(t, thread.Walk(func(node *CommentNode) error {
+		visited = append(visited, strings.Repeat("-", node.Depth)+node.Id)
+		return nil
+	}))
+	// 최상위, 답글 모두 작성시간 순, 부모가 없는 답글(6) 은 최상위로
+	assert.Equal(t, []string{"5", "1", "-2", "--4", "-3", "6"}, visited)
+
+	root, ok := thread.Find("1")
+	assert.True(t, ok)
+	assert.Equal(t, 4, root.Size())
+	assert.Equal(t, 2, root.CountPending())
+	assert.Equal(t, 1, root.CountSecret())
+
+	reply, _ := thread.Find("4")
+	assert.Equal(t, "2", reply.ParentNode.Id)
+	assert.True(t, reply.Pending())
+	assert.False(t, reply.Secret())
+
+	assert.Equal(t, 2, thread.CountPending())
+	assert.Equal(t, 2, thread.CountSecret())
+
+	_, ok = thread.Find("999")
+	assert.False(t, ok)
+}
+
+func TestBuildCommentThread_Cycle(t *testing.T) {
+	at := func(minute int) TistoryTime {
+		return NewUnixTime(time.Date(2023, 1, 1, 0, minute, 0, 0, time.UTC))
+	}
+	// 1 -> 2 -> 1 순환과 그 아래 답글 (3)
+	item := CommentListItem{}
+	item.Comments.Comment = []CommentListItemData{
+		{Id: "1", Date: at(1), ParentId: "2"},
+		{Id: "2", Date: at(2), ParentId: "1"},
+		{Id: "3", Date: at(3), ParentId: "1"},
+	}
+	thread := BuildCommentThread(item)
+
+	var visited []string
+	assert.NoError(t, thread.Walk(func(node *CommentNode) error {
+		visited = append(visited, strings.Repeat("-", node.Depth)+node.Id)
+		return nil
+	}))
+	// 순환을 닫는 댓글 (2) 이 최상위가 되고 빠지는 댓글이 없다.
+	assert.Equal(t, []string{"2", "-1", "--3"}, visited)
+	assert.Equal(t, 3, thread.Roots[0].Size())
+}
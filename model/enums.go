@@ -0,0 +1,105 @@
+package model
+
+import (
+	"fmt"
+)
+
+// Visibility 포스트 공개 여부 상태
+// 글쓰기/상세 API 는 0, 1, 3 을, 글 목록 API 는 같은 상태를 0, 15, 20 으로 표현한다.
+// 두 코드를 모두 받고, 인코딩과 요청 파라미터는 글쓰기 API 코드(0, 1, 3) 로 보낸다.
+// 응답의 모르는 코드는 에러 없이 그대로 담는다. (검사는 ParseVisibility, Validate 에서 한다)
+// 빈 값은 "지정 안함" 으로 요청시 파라미터를 보내지 않는다. (Tistory 기본값: 비공개)
+type Visibility string
+
+const (
+	// Private 비공개 (0)
+	Private Visibility = "0"
+	// Protected 보호 (1, 글 목록: 15)
+	Protected Visibility = "1"
+	// Public 발행 (3, 글 목록: 20)
+	Public Visibility = "3"
+)
+
+// ParseVisibility 글쓰기/상세 코드(0, 1, 3) 와 글 목록 코드(15, 20) 해석
+func ParseVisibility(raw string) (Visibility, error) {
+	switch raw {
+	case "", "0", "1", "3":
+		return Visibility(raw), nil
+	case "15":
+		return Protected, nil
+	case "20":
+		return Public, nil
+	}
+	return "", fmt.Errorf("model: invalid visibility %q", raw)
+}
+
+// String 글쓰기 API 코드
+func (v Visibility) String() string {
+	return string(v)
+}
+
+func (v *Visibility) UnmarshalJSON(data []byte) error {
+	raw, err := unquote(data)
+	if err != nil {
+		return err
+	}
+	parsed, err := ParseVisibility(raw)
+	if err != nil {
+		// 서버에 새 코드가 생겨도 읽기 API 가 깨지지 않게 원본 코드를 그대로 담는다.
+		parsed = Visibility(raw)
+	}
+	*v = parsed
+	return nil
+}
+
+// CommentPolicy 포스트 댓글 허용 여부
+// 빈 값은 "지정 안함" 으로 요청시 파라미터를 보내지 않는다. (Tistory 기본값: 허용)
+// 응답의 모르는 코드는 에러 없이 그대로 담는다. (검사는 Validate 에서 한다)
+type CommentPolicy string
+
+const (
+	// CommentDenied 댓글 거부 (0)
+	CommentDenied CommentPolicy = "0"
+	// CommentAllowed 댓글 허용 (1)
+	CommentAllowed CommentPolicy = "1"
+)
+
+// String API 코드
+func (p CommentPolicy) String() string {
+	return string(p)
+}
+
+func (p *CommentPolicy) UnmarshalJSON(data []byte) error {
+	raw, err := unquote(data)
+	if err != nil {
+		return err
+	}
+	*p = CommentPolicy(raw)
+	return nil
+}
+
+// CommentSecrecy 댓글 비밀글 여부
+// 빈 값은 "지정 안함" 으로 요청시 파라미터를 보내지 않는다. (Tistory 기본값: 공개)
+// 응답의 모르는 코드는 에러 없이 그대로 담는다. (검사는 Validate 에서 한다)
+type CommentSecrecy string
+
+const (
+	// CommentPublic 공개 댓글 (0)
+	CommentPublic CommentSecrecy = "0"
+	// CommentSecret 비밀 댓글 (1)
+	CommentSecret CommentSecrecy = "1"
+)
+
+// String API 코드
+func (c CommentSecrecy) String() string {
+	return string(c)
+}
+
+func (c *CommentSecrecy) UnmarshalJSON(data []byte) error {
+	raw, err := unquote(data)
+	if err != nil {
+		return err
+	}
+	*c = CommentSecrecy(raw)
+	return nil
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVisibility(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Visibility
+		wantErr bool
+	}{
+		{name: "비공개:[success]", data: `"0"`, want: Private},
+		{name: "보호 (상세 코드):[success]", data: `"1"`, want: Protected},
+		{name: "보호 (목록 코드):[success]", data: `"15"`, want: Protected},
+		{name: "발행 (상세 코드):[success]", data: `"3"`, want: Public},
+		{name: "발행 (목록 코드 숫자):[success]", data: `20`, want: Public},
+		{name: "지정 안함:[success]", data: `""`, want: ""},
+		{name: "알 수 없는 코드는 그대로:[success]", data: `"2"`, want: Visibility("2")},
+		{name: "잘못된 JSON:[failure]", data: `"1`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Visibility
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// 목록 코드로 받아도 글쓰기 코드로 인코딩된다.
+	var item PostListItemData
+	assert.NoError(t, json.Unmarshal([]byte(`{"visibility":"20"}`), &item))
+	data, err := json.Marshal(PostData{Visibility: item.Visibility})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"visibility":"3"`)
+}
+
+func TestCommentEnums(t *testing.T) {
+	var data PostDetailItem
+	assert.NoError(t, json.Unmarshal([]byte(`{"acceptComment":"0"}`), &data))
+	assert.Equal(t, CommentDenied, data.AcceptComment)
+	assert.NoError(t, json.Unmarshal([]byte(`{"acceptComment":"2"}`), &data))
+	assert.Equal(t, CommentPolicy("2"), data.AcceptComment)
+
+	var comment CommentData
+	assert.NoError(t, json.Unmarshal([]byte(`{"secret":"1"}`), &comment))
+	assert.Equal(t, CommentSecret, comment.Secret)
+	assert.NoError(t, json.Unmarshal([]byte(`{"secret":"Y"}`), &comment))
+	assert.Equal(t, CommentSecrecy("Y"), comment.Secret)
+}
+
+func TestEnums_UnknownCodeInResponse(t *testing.T) {
+	// 모르는 코드가 있어도 응답 전체를 읽을 수 있어야 한다.
+	var post PostResult[PostDetailItem]
+	err := json.Unmarshal([]byte(`{"status":"200","item":{"id":"1","title":"글","visibility":"7","acceptComment":"9"}}`), &post)
+	assert.NoError(t, err)
+	assert.Equal(t, "글", post.Item.Title)
+	assert.Equal(t, Visibility("7"), post.Item.Visibility)
+	assert.Equal(t, CommentPolicy("9"), post.Item.AcceptComment)
+
+	// 요청 검사는 그대로 엄격하다.
+	_, err = ParseVisibility("7")
+	assert.Error(t, err)
+	assert.ErrorIs(t, PostData{BlogName: "b", Title: "t", Visibility: post.Item.Visibility}.Validate(), ErrInvalidData)
+}
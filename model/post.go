@@ -28,7 +28,7 @@ type PostData struct {
 	Content string `json:"content"`
 
 	// Visibility 포스트 공개 여부 상태 (0: 비공개[default], 1: 보호, 3: 발행)
-	Visibility string `json:"visibility"`
+	Visibility Visibility `json:"visibility"`
 
 	// Category 포스트 카테고리 (0: 없음[default], 카테고리 ID: 미리 블로그에 생성해둔 ID, 이 카테고리는 카테고리 목록 읽기 API를 통해 확인 가능하다.)
 	Category string `json:"category"`
@@ -43,7 +43,7 @@ type PostData struct {
 	Tag string `json:"tag"`
 
 	// AcceptComment 댓글 허용 (0: 댓글 거부, 1: 댓글 허용[default])
-	AcceptComment string `json:"acceptComment"`
+	AcceptComment CommentPolicy `json:"acceptComment"`
 
 	// Password 보호글용 비밀 번호 (비워두면 공개)
 	Password string `json:"password"`
@@ -101,7 +101,7 @@ type PostWriteResult struct {
 // Tags	[] Tag 		태그 목록
 // Comments			댓글 개수
 // Trackbacks		추적 개수
-// Date				발생시간 (시간[TIMESTAMP], milliseconds)
+// Date				발생시간 (시간[TIMESTAMP], 초 또는 milliseconds, 원문은 Date.Raw)
 type PostDetailItem struct {
 	// Url	티스토리 기본 URL
 	Url string `json:"url"`
@@ -125,10 +125,10 @@ type PostDetailItem struct {
 	PostUrl string `json:"postUrl"`
 
 	// Visibility	포스트 공개 여부 상태 (0: 비공개[default], 1: 보호, 3: 발행)
-	Visibility string `json:"visibility"`
+	Visibility Visibility `json:"visibility"`
 
 	// AcceptComment	댓글 허용 여부 (0: 댓글 거부, 1: 댓글 허용[default])
-	AcceptComment string `json:"acceptComment"`
+	AcceptComment CommentPolicy `json:"acceptComment"`
 
 	// AcceptTrackback	추적 허용 여부 (자세히 파악하지 못한 내용이다. 가이드에도 친절하게 나와있진 않다.)
 	AcceptTrackback string `json:"acceptTrackback"`
@@ -139,13 +139,13 @@ type PostDetailItem struct {
 	} `json:"tags"`
 
 	// Comments	댓글 개수
-	Comments string `json:"comments"`
+	Comments FlexInt `json:"comments"`
 
 	// Trackbacks	추적 개수
-	Trackbacks string `json:"trackbacks"`
+	Trackbacks FlexInt `json:"trackbacks"`
 
-	// Date	발생시간 (시간[TIMESTAMP], milliseconds)
-	Date string `json:"date"`
+	// Date	발생시간 (시간[TIMESTAMP], 초 또는 milliseconds, 원문은 Date.Raw)
+	Date TistoryTime `json:"date"`
 }
 
 // PostListItem 포스트 목록 보기용 Item 타입
@@ -163,13 +163,13 @@ type PostListItem struct {
 	SecondaryUrl string `json:"secondaryUrl"`
 
 	// Page	현재 페이지
-	Page string `json:"page"`
+	Page FlexInt `json:"page"`
 
 	// Count	현재 페이지 글 개수
-	Count string `json:"count"`
+	Count FlexInt `json:"count"`
 
 	// TotalCount	전체 글 수
-	TotalCount string `json:"totalCount"`
+	TotalCount FlexInt `json:"totalCount"`
 
 	// Posts [] PostListItemData	글 리스트
 	Posts []PostListItemData `json:"posts"`
@@ -179,11 +179,11 @@ type PostListItem struct {
 // Id			포스트 ID
 // Title		포스트 제목
 // PostUrl		포스트 URL
-// Visibility	포스트 공개 여부 상태 (0: 비공개[default], 15: 보호, 20: 발행)
+// Visibility	포스트 공개 여부 상태 (응답 코드 0: 비공개, 15: 보호, 20: 발행 을 Private, Protected, Public 으로 해석한다.)
 // CategoryId	카테고리 ID
 // Comments		댓글 수
 // Trackbacks	트랙백 수
-// Date			발행 시간 (시간[TIMESTAMP], YYYY-mm-dd HH:MM:SS)
+// Date			발행 시간 (YYYY-mm-dd HH:MM:SS, KST, 원문은 Date.Raw)
 type PostListItemData struct {
 	// Id	포스트 ID
 	Id string `json:"id"`
@@ -194,18 +194,18 @@ type PostListItemData struct {
 	// PostUrl	포스트 URL
 	PostUrl string `json:"postUrl"`
 
-	// Visibility	포스트 공개 여부 상태 (0: 비공개[default], 15: 보호, 20: 발행)
-	Visibility string `json:"visibility"`
+	// Visibility	포스트 공개 여부 상태 (응답 코드 0: 비공개, 15: 보호, 20: 발행 을 Private, Protected, Public 으로 해석한다.)
+	Visibility Visibility `json:"visibility"`
 
 	// CategoryId	카테고리 ID
 	CategoryId string `json:"categoryId"`
 
 	// Comments		댓글 수
-	Comments string `json:"comments"`
+	Comments FlexInt `json:"comments"`
 
 	// Trackbacks	트랙백 수
-	Trackbacks string `json:"trackbacks"`
+	Trackbacks FlexInt `json:"trackbacks"`
 
-	// Date		발행 시간 (시간[TIMESTAMP], YYYY-mm-dd HH:MM:SS)
-	Date string `json:"date"`
+	// Date		발행 시간 (YYYY-mm-dd HH:MM:SS, KST, 원문은 Date.Raw)
+	Date TistoryTime `json:"date"`
 }
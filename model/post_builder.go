@@ -0,0 +1,142 @@
+package model
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PostBuilder PostData 를 체이닝으로 만드는 빌더
+// 사용법:
+//
+//	data, err := model.NewPost("blogName", "제목").
+//		HTML("<p>본문</p>").
+//		Tags("go", "tistory").
+//		PublishAt(time.Now().Add(time.Hour)).
+//		Build()
+type PostBuilder struct {
+	data PostData
+}
+
+// NewPost 블로그 명, 제목으로 빌더 생성
+func NewPost(blogName, title string) *PostBuilder {
+	return &PostBuilder{data: PostData{BlogName: blogName, Title: title}}
+}
+
+// HTML 포스트 내용
+func (b *PostBuilder) HTML(content string) *PostBuilder {
+	b.data.Content = content
+	return b
+}
+
+// Tags 태그 목록 (앞뒤 공백은 지우고 빈 태그는 버린다)
+func (b *PostBuilder) Tags(tags ...string) *PostBuilder {
+	b.data.Tag = joinTags(tags)
+	return b
+}
+
+// Category 카테고리 ID
+func (b *PostBuilder) Category(id string) *PostBuilder {
+	b.data.Category = id
+	return b
+}
+
+// Visibility 공개 여부 상태 (Private, Protected, Public)
+func (b *PostBuilder) Visibility(visibility Visibility) *PostBuilder {
+	b.data.Visibility = visibility
+	return b
+}
+
+// Protected 비밀번호가 걸린 보호글
+func (b *PostBuilder) Protected(password string) *PostBuilder {
+	b.data.Visibility = Protected
+	b.data.Password = password
+	return b
+}
+
+// PublishAt 배포 시간, 현재보다 미래면 예약 발행으로 동작한다.
+func (b *PostBuilder) PublishAt(t time.Time) *PostBuilder {
+	b.data.Published = strconv.FormatInt(t.Unix(), 10)
+	return b
+}
+
+// Slug 문자 주소 (/entry/문자주소)
+func (b *PostBuilder) Slug(slogan string) *PostBuilder {
+	b.data.Slogan = slogan
+	return b
+}
+
+// DisableComments 댓글 거부
+func (b *PostBuilder) DisableComments() *PostBuilder {
+	b.data.AcceptComment = CommentDenied
+	return b
+}
+
+// Build Validate 를 통과한 PostData 반환
+func (b *PostBuilder) Build() (PostData, error) {
+	if err := b.data.Validate(); err != nil {
+		return PostData{}, err
+	}
+	return b.data, nil
+}
+
+// UpdateFrom 기존 글 상세 데이터로 글 수정용 DTO 생성
+// 글 수정 API 는 보내지 않은 값을 기본값으로 덮어쓰므로, 일부만 고칠 때는 이 값에서 필요한 필드만 바꿔서 보낸다.
+// 블로그 명은 Url 의 'xxx.tistory.com' 에서, 문자 주소는 PostUrl 의 '/entry/' 뒤에서 꺼낸다.
+// 보호글 비밀번호는 상세 API 가 내려주지 않으므로 다시 넣어야 한다.
+func UpdateFrom(item PostDetailItem) PostUpdateData {
+	data := PostUpdateData{
+		PostId: item.Id,
+		PostData: PostData{
+			BlogName:      blogNameFromUrl(item.Url),
+			Title:         item.Title,
+			Content:       item.Content,
+			Visibility:    item.Visibility,
+			Category:      item.CategoryId,
+			Slogan:        sloganFromUrl(item.PostUrl),
+			Tag:           joinTags(item.Tags.Tag),
+			AcceptComment: item.AcceptComment,
+		},
+	}
+	if !item.Date.IsZero() {
+		data.Published = strconv.FormatInt(item.Date.Unix(), 10)
+	}
+	return data
+}
+
+func joinTags(tags []string) string {
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			result = append(result, tag)
+		}
+	}
+	return strings.Join(result, ",")
+}
+
+// blogNameFromUrl 'https://xxx.tistory.com' => 'xxx'
+func blogNameFromUrl(rawUrl string) string {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return ""
+	}
+	name, _, found := strings.Cut(u.Hostname(), ".")
+	if !found {
+		return ""
+	}
+	return name
+}
+
+// sloganFromUrl 'https://xxx.tistory.com/entry/slogan' => 'slogan'
+func sloganFromUrl(rawUrl string) string {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return ""
+	}
+	_, slogan, found := strings.Cut(u.Path, "/entry/")
+	if !found {
+		return ""
+	}
+	return slogan
+}
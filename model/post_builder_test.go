@@ -0,0 +1,92 @@
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPost(t *testing.T) {
+	publishAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name    string
+		builder *PostBuilder
+		want    PostData
+		wantErr bool
+	}{
+		{
+			name:    "기본 글:[success]",
+			builder: NewPost("blog", "제목").HTML("<p>본문</p>"),
+			want:    PostData{BlogName: "blog", Title: "제목", Content: "<p>본문</p>"},
+		},
+		{
+			name: "예약 보호글:[success]",
+			builder: NewPost("blog", "제목").
+				Tags(" go ", "", "tistory").
+				Category("12").
+				PublishAt(publishAt).
+				Protected("1234").
+				Slug("hello-world").
+				DisableComments(),
+			want: PostData{
+				BlogName:      "blog",
+				Title:         "제목",
+				Tag:           "go,tistory",
+				Category:      "12",
+				Published:     "1893553445",
+				Visibility:    Protected,
+				Password:      "1234",
+				Slogan:        "hello-world",
+				AcceptComment: CommentDenied,
+			},
+		},
+		{
+			name:    "제목 누락:[failure]",
+			builder: NewPost("blog", ""),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.builder.Build()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidData)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUpdateFrom(t *testing.T) {
+	item := PostDetailItem{
+		Url:           "https://sample.tistory.com",
+		Id:            "74",
+		Title:         "제목",
+		Content:       "<p>본문</p>",
+		CategoryId:    "12",
+		PostUrl:       "https://sample.tistory.com/entry/hello-world",
+		Visibility:    Public,
+		AcceptComment: CommentDenied,
+		Date:          NewUnixTime(time.Unix(1303352668, 0)),
+	}
+	item.Tags.Tag = []string{"open", "api"}
+
+	got := UpdateFrom(item)
+	assert.Equal(t, PostUpdateData{
+		PostId: "74",
+		PostData: PostData{
+			BlogName:      "sample",
+			Title:         "제목",
+			Content:       "<p>본문</p>",
+			Visibility:    Public,
+			Category:      "12",
+			Published:     "1303352668",
+			Slogan:        "hello-world",
+			Tag:           "open,api",
+			AcceptComment: CommentDenied,
+		},
+	}, got)
+	assert.NoError(t, got.Validate())
+}
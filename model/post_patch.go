@@ -0,0 +1,77 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+// PostPatch 글 부분 수정용 DTO
+// nil 인 필드는 기존 값을 유지하고, nil 이 아닌 필드만 덮어쓴다. (빈 값으로 지우려면 빈 값의 포인터를 넣는다)
+// Title			포스트 제목
+// Content			포스트 내용
+// Visibility		포스트 공개 여부 상태
+// Category			카테고리 ID
+// Published		배포 시간
+// Slogan			문자 주소
+// Tags				태그 목록
+// AcceptComment	댓글 허용 여부
+// Password			보호글용 비밀 번호
+type PostPatch struct {
+	// Title 포스트 제목
+	Title *string
+
+	// Content 포스트 내용
+	Content *string
+
+	// Visibility 포스트 공개 여부 상태
+	Visibility *Visibility
+
+	// Category 카테고리 ID
+	Category *string
+
+	// Published 배포 시간
+	Published *time.Time
+
+	// Slogan 문자 주소
+	Slogan *string
+
+	// Tags 태그 목록
+	Tags *[]string
+
+	// AcceptComment 댓글 허용 여부
+	AcceptComment *CommentPolicy
+
+	// Password 보호글용 비밀 번호
+	Password *string
+}
+
+// Apply 설정된 필드만 data 에 덮어쓴다.
+func (p PostPatch) Apply(data *PostUpdateData) {
+	if p.Title != nil {
+		data.Title = *p.Title
+	}
+	if p.Content != nil {
+		data.Content = *p.Content
+	}
+	if p.Visibility != nil {
+		data.Visibility = *p.Visibility
+	}
+	if p.Category != nil {
+		data.Category = *p.Category
+	}
+	if p.Published != nil {
+		data.Published = strconv.FormatInt(p.Published.Unix(), 10)
+	}
+	if p.Slogan != nil {
+		data.Slogan = *p.Slogan
+	}
+	if p.Tags != nil {
+		data.Tag = joinTags(*p.Tags)
+	}
+	if p.AcceptComment != nil {
+		data.AcceptComment = *p.AcceptComment
+	}
+	if p.Password != nil {
+		data.Password = *p.Password
+	}
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// Token 엑세스 토큰 DTO
+// AccessToken	엑세스 토큰 값
+// ObtainedAt	발급 시간 (직접 넘긴 토큰은 알 수 없으므로 zero value)
+// Raw			토큰 발급 응답 원문 (직접 넘긴 토큰은 빈 문자열)
+type Token struct {
+	// AccessToken 엑세스 토큰 값
+	AccessToken string `json:"access_token"`
+
+	// ObtainedAt 발급 시간 (직접 넘긴 토큰은 알 수 없으므로 zero value)
+	ObtainedAt time.Time `json:"obtained_at"`
+
+	// Raw 토큰 발급 응답 원문 (직접 넘긴 토큰은 빈 문자열)
+	Raw string `json:"raw"`
+}
@@ -0,0 +1,165 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Tistory 응답은 숫자, 시간, 여부 값을 대부분 문자열로 내려준다.
+// 같은 필드라도 API 마다 "12" 와 12, 초와 밀리초, "YYYY-mm-dd HH:MM:SS" 와 TIMESTAMP 처럼 표현이 달라서
+// 아래 타입들은 두 표현을 모두 받고, 다시 인코딩할 때는 Tistory 의 문자열 표현으로 돌려준다.
+
+// KST Tistory 가 "YYYY-mm-dd HH:MM:SS" 형식에 쓰는 시간대 (UTC+9)
+var KST = time.FixedZone("KST", 9*60*60)
+
+// TistoryDateLayout Tistory 의 "YYYY-mm-dd HH:MM:SS" 형식
+const TistoryDateLayout = "2006-01-02 15:04:05"
+
+// unquote JSON 문자열이면 따옴표를 벗기고, 숫자/불리언이면 그대로 돌려준다.
+// null 은 빈 문자열로 취급한다.
+func unquote(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return "", nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return "", err
+		}
+		return s, nil
+	}
+	return string(data), nil
+}
+
+// FlexInt 문자열("12") 과 숫자(12) 를 모두 받는 정수
+// 빈 문자열과 null 은 0 으로 해석한다.
+// TistoryTime 과 달리 응답 원문은 남기지 않는다. 원문이 "012" 처럼 달라도 String() 은 "12" 를 반환한다.
+type FlexInt int64
+
+// Int int 로 변환
+func (i FlexInt) Int() int {
+	return int(i)
+}
+
+// String Tistory 문자열 표현 ("12")
+func (i FlexInt) String() string {
+	return strconv.FormatInt(int64(i), 10)
+}
+
+func (i FlexInt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+func (i *FlexInt) UnmarshalJSON(data []byte) error {
+	raw, err := unquote(data)
+	if err != nil {
+		return err
+	}
+	if raw == "" {
+		*i = 0
+		return nil
+	}
+	n, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return fmt.Errorf("model: invalid integer %q: %w", raw, err)
+	}
+	*i = FlexInt(n)
+	return nil
+}
+
+// TistoryTime TIMESTAMP (초, 밀리초) 와 "YYYY-mm-dd HH:MM:SS" (KST) 를 모두 받는 시간
+// Raw 에는 응답 원문이 그대로 남으므로 기존처럼 문자열이 필요하면 Raw 를 쓰면 된다.
+// 빈 문자열과 null 은 zero 시간으로 해석한다.
+type TistoryTime struct {
+	time.Time
+
+	// Raw 응답 원문
+	Raw string
+}
+
+// NewUnixTime TIMESTAMP(초) 로 표현되는 TistoryTime 생성
+func NewUnixTime(t time.Time) TistoryTime {
+	return TistoryTime{Time: t, Raw: strconv.FormatInt(t.Unix(), 10)}
+}
+
+// NewDateTime "YYYY-mm-dd HH:MM:SS" (KST) 로 표현되는 TistoryTime 생성
+func NewDateTime(t time.Time) TistoryTime {
+	t = t.In(KST).Truncate(time.Second)
+	return TistoryTime{Time: t, Raw: t.Format(TistoryDateLayout)}
+}
+
+// ParseTistoryTime Tistory 시간 문자열 해석
+// 숫자면 TIMESTAMP 로 보고 13자리 이상이면 밀리초, 아니면 초 단위로 해석한다.
+// 가이드는 milliseconds 라고 하지만 실제 응답은 초 단위인 경우가 있기 때문이다.
+func ParseTistoryTime(raw string) (TistoryTime, error) {
+	if raw == "" {
+		return TistoryTime{}, nil
+	}
+	if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		if n >= 1e12 {
+			return TistoryTime{Time: time.UnixMilli(n), Raw: raw}, nil
+		}
+		return TistoryTime{Time: time.Unix(n, 0), Raw: raw}, nil
+	}
+	t, err := time.ParseInLocation(TistoryDateLayout, raw, KST)
+	if err != nil {
+		return TistoryTime{}, fmt.Errorf("model: invalid time %q: %w", raw, err)
+	}
+	return TistoryTime{Time: t, Raw: raw}, nil
+}
+
+// String Tistory 문자열 표현 (응답 원문)
+func (t TistoryTime) String() string {
+	return t.Raw
+}
+
+func (t TistoryTime) MarshalJSON() ([]byte, error) {
+	raw := t.Raw
+	if raw == "" && !t.IsZero() {
+		raw = strconv.FormatInt(t.Unix(), 10)
+	}
+	return json.Marshal(raw)
+}
+
+func (t *TistoryTime) UnmarshalJSON(data []byte) error {
+	raw, err := unquote(data)
+	if err != nil {
+		return err
+	}
+	parsed, err := ParseTistoryTime(raw)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// YN "Y"/"N" 여부 값
+// 불리언(true/false) 과 빈 문자열(false) 도 받는다. 그 외의 값은 응답 전체가 실패하지 않도록 false 로 해석한다.
+// TistoryTime 과 달리 응답 원문은 남기지 않는다. String() 은 항상 "Y" 또는 "N" 이다.
+type YN bool
+
+// String Tistory 문자열 표현 ("Y", "N")
+func (yn YN) String() string {
+	if yn {
+		return "Y"
+	}
+	return "N"
+}
+
+func (yn YN) MarshalJSON() ([]byte, error) {
+	return json.Marshal(yn.String())
+}
+
+func (yn *YN) UnmarshalJSON(data []byte) error {
+	raw, err := unquote(data)
+	if err != nil {
+		return err
+	}
+	*yn = raw == "Y" || raw == "y" || raw == "true"
+	return nil
+}
@@ -0,0 +1,123 @@
+package model
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFlexInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    FlexInt
+		wantErr bool
+	}{
+		{name: "문자열:[success]", data: `"181"`, want: 181},
+		{name: "숫자:[success]", data: `181`, want: 181},
+		{name: "빈 문자열:[success]", data: `""`, want: 0},
+		{name: "null:[success]", data: `null`, want: 0},
+		{name: "숫자가 아닌 문자열:[failure]", data: `"abc"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got FlexInt
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	data, err := json.Marshal(FlexInt(10))
+	assert.NoError(t, err)
+	assert.Equal(t, `"10"`, string(data))
+}
+
+func TestTistoryTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "TIMESTAMP 초:[success]", data: `"1303352668"`, want: time.Unix(1303352668, 0)},
+		{name: "TIMESTAMP 밀리초:[success]", data: `"1303352668123"`, want: time.UnixMilli(1303352668123)},
+		{name: "TIMESTAMP 숫자:[success]", data: `1303352668`, want: time.Unix(1303352668, 0)},
+		{name: "날짜 문자열 (KST):[success]", data: `"2018-06-01 17:54:28"`, want: time.Date(2018, 6, 1, 8, 54, 28, 0, time.UTC)},
+		{name: "빈 문자열:[success]", data: `""`},
+		{name: "알 수 없는 형식:[failure]", data: `"2018/06/01"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got TistoryTime
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tt.want.Equal(got.Time), "want %v, got %v", tt.want, got.Time)
+
+			// 원문은 그대로 다시 인코딩된다.
+			data, err := json.Marshal(got)
+			assert.NoError(t, err)
+			var raw string
+			assert.NoError(t, json.Unmarshal(data, &raw))
+			assert.Equal(t, got.Raw, raw)
+		})
+	}
+
+	at := time.Date(2018, 6, 1, 8, 54, 28, 0, time.UTC)
+	assert.Equal(t, "2018-06-01 17:54:28", NewDateTime(at).Raw)
+	assert.Equal(t, "1527843268", NewUnixTime(at).Raw)
+}
+
+func TestYN(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    YN
+		wantErr bool
+	}{
+		{name: "Y:[success]", data: `"Y"`, want: true},
+		{name: "N:[success]", data: `"N"`, want: false},
+		{name: "불리언:[success]", data: `true`, want: true},
+		{name: "빈 문자열:[success]", data: `""`, want: false},
+		{name: "null:[success]", data: `null`, want: false},
+		{name: "알 수 없는 값은 false:[success]", data: `"X"`, want: false},
+		{name: "잘못된 JSON:[failure]", data: `"Y`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got YN
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	data, err := json.Marshal(YN(true))
+	assert.NoError(t, err)
+	assert.Equal(t, `"Y"`, string(data))
+}
+
+func TestTyping_Typed(t *testing.T) {
+	data := `{"tistory":{"status":"200","item":{"page":"1","count":"1","totalCount":"181","posts":[
+		{"id":"201","comments":"3","trackbacks":"0","date":"2018-06-01 17:54:28"}]}}}`
+
+	got := TistoryResult[PostResult[PostListItem], PostListItem, EmptyType]{}
+	assert.NoError(t, json.Unmarshal([]byte(data), &got))
+	assert.Equal(t, 181, got.Tistory.Item.TotalCount.Int())
+	assert.Equal(t, FlexInt(3), got.Tistory.Item.Posts[0].Comments)
+	assert.Equal(t, "2018-06-01 17:54:28", got.Tistory.Item.Posts[0].Date.Raw)
+	assert.Equal(t, 2018, got.Tistory.Item.Posts[0].Date.Year())
+}
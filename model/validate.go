@@ -0,0 +1,109 @@
+package model
+
+import (
+	"errors"
+)
+
+// ErrInvalidData 요청 DTO 검증 실패
+// Validate 가 반환한 에러는 errors.Is(err, ErrInvalidData) 로 판별할 수 있다.
+var ErrInvalidData = errors.New("model: invalid data")
+
+// FieldError 필드 하나의 검증 실패
+// Field	json 태그 기준 필드 명
+// Message	실패 이유
+type FieldError struct {
+	// Field json 태그 기준 필드 명
+	Field string
+
+	// Message 실패 이유
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return "model: " + e.Field + ": " + e.Message
+}
+
+func (e *FieldError) Is(target error) bool {
+	return target == ErrInvalidData
+}
+
+// validator 검증 실패를 모아서 한번에 반환한다.
+type validator []error
+
+func (v *validator) check(ok bool, field, message string) {
+	if !ok {
+		*v = append(*v, &FieldError{Field: field, Message: message})
+	}
+}
+
+func (v *validator) required(value, field string) {
+	v.check(value != "", field, "필수값입니다.")
+}
+
+// err 모든 실패를 errors.Join 으로 묶는다. (실패가 없으면 nil)
+func (v validator) err() error {
+	return errors.Join(v...)
+}
+
+// Validate 글쓰기 전 검증
+// 필수값(BlogName, Title), 공개 여부/댓글 허용 코드, 보호글이 아닌데 비밀번호가 있는지를 검사한다.
+// 실패한 항목을 모두 모아 errors.Join 으로 반환한다.
+func (d PostData) Validate() error {
+	v := validator{}
+	d.validate(&v)
+	return v.err()
+}
+
+func (d PostData) validate(v *validator) {
+	v.required(d.BlogName, "blog_name")
+	v.required(d.Title, "title")
+
+	switch d.Visibility {
+	case "", Private, Protected, Public:
+	default:
+		v.check(false, "visibility", "알 수 없는 공개 여부 코드입니다: "+d.Visibility.String())
+	}
+	v.check(d.Password == "" || d.Visibility == Protected, "password", "비밀번호는 보호글(Protected)에만 쓸 수 있습니다.")
+
+	switch d.AcceptComment {
+	case "", CommentDenied, CommentAllowed:
+	default:
+		v.check(false, "acceptComment", "알 수 없는 댓글 허용 코드입니다: "+d.AcceptComment.String())
+	}
+}
+
+// Validate 글 수정 전 검증 (PostId 필수 + PostData.Validate)
+func (d PostUpdateData) Validate() error {
+	v := validator{}
+	v.required(d.PostId, "post_id")
+	d.PostData.validate(&v)
+	return v.err()
+}
+
+// Validate 댓글 쓰기 전 검증
+// 필수값(BlogName, PostId, Content) 과 비밀 댓글 코드를 검사한다.
+func (d CommentData) Validate() error {
+	v := validator{}
+	d.validate(&v)
+	return v.err()
+}
+
+func (d CommentData) validate(v *validator) {
+	v.required(d.BlogName, "blog_name")
+	v.required(d.PostId, "post_id")
+	v.required(d.Content, "content")
+
+	switch d.Secret {
+	case "", CommentPublic, CommentSecret:
+	default:
+		v.check(false, "secret", "알 수 없는 비밀 댓글 코드입니다: "+d.Secret.String())
+	}
+}
+
+// Validate 댓글 수정 전 검증 (CommentId 필수 + CommentData.Validate)
+func (d CommentUpdateData) Validate() error {
+	v := validator{}
+	v.required(d.CommentId, "comment_id")
+	d.CommentData.validate(&v)
+	return v.err()
+}
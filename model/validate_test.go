@@ -0,0 +1,80 @@
+package model
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// fields 에러에 담긴 FieldError 의 필드 명 목록
+func fields(err error) []string {
+	var result []string
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			var fieldErr *FieldError
+			if errors.As(e, &fieldErr) {
+				result = append(result, fieldErr.Field)
+			}
+		}
+	}
+	return result
+}
+
+func TestPostData_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		data interface{ Validate() error }
+		want []string
+	}{
+		{
+			name: "글쓰기:[success]",
+			data: PostData{BlogName: "blog", Title: "제목", Visibility: Protected, Password: "1234", Tag: "a,b"},
+		},
+		{
+			name: "태그 수 제한 없음:[success]",
+			data: PostData{BlogName: "blog", Title: "제목", Tag: strings.Repeat("tag,", 30) + "tag"},
+		},
+		{
+			name: "필수값 누락:[failure]",
+			data: PostData{},
+			want: []string{"blog_name", "title"},
+		},
+		{
+			name: "보호글이 아닌데 비밀번호:[failure]",
+			data: PostData{BlogName: "blog", Title: "제목", Visibility: Public, Password: "1234"},
+			want: []string{"password"},
+		},
+		{
+			name: "알 수 없는 코드:[failure]",
+			data: PostData{BlogName: "blog", Title: "제목", Visibility: "20", AcceptComment: "Y"},
+			want: []string{"visibility", "acceptComment"},
+		},
+		{
+			name: "글 수정 PostId 누락:[failure]",
+			data: PostUpdateData{PostData: PostData{BlogName: "blog"}},
+			want: []string{"post_id", "title"},
+		},
+		{
+			name: "댓글 쓰기 필수값 누락:[failure]",
+			data: CommentData{Secret: "Y"},
+			want: []string{"blog_name", "post_id", "content", "secret"},
+		},
+		{
+			name: "댓글 수정 CommentId 누락:[failure]",
+			data: CommentUpdateData{CommentData: CommentData{BlogName: "blog", PostId: "1", Content: "댓글"}},
+			want: []string{"comment_id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.data.Validate()
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidData)
+			assert.Equal(t, tt.want, fields(err))
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Package oauth 셀레니움 없이 Authorization Code 를 받는 로컬 콜백 서버
+// 127.0.0.1 에 http 서버를 띄우고 인증 URL 을 출력(또는 브라우저로 열기)한 뒤,
+// Tistory 가 redirect_uri 로 돌려보낸 요청에서 state 를 검증하고 code 를 꺼낸다.
+// Tistory Open API 앱 설정의 Callback 경로를 RedirectUrl() 과 같게 등록해야 한다.
+// state 가 맞지 않는 요청은 400 으로 응답하고 올바른 콜백이 올 때까지 계속 기다린다.
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/fineroot1253/tistoryAPI"
+	"github.com/fineroot1253/tistoryAPI/internal/state"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+)
+
+var (
+	// ErrStateMismatch 리다이렉트된 state 가 요청한 state 와 다른 경우 (CSRF 의심)
+	ErrStateMismatch = state.ErrMismatch
+	// ErrNoCode 리다이렉트된 요청에 code 가 없는 경우
+	ErrNoCode = errors.New("tistoryAPI/oauth: no authorization code in redirect")
+	// ErrAuthorizationDenied 사용자가 인증을 거부했거나 Tistory 가 error 로 리다이렉트한 경우
+	ErrAuthorizationDenied = errors.New("tistoryAPI/oauth: authorization denied")
+)
+
+// Config 로컬 콜백 서버 설정
+// ClientId		Tistory Open API 에 블로그 등록시 발급받는 ClientId (필수)
+// Port			콜백 서버 포트 (0: 임의 포트, 테스트용)
+// CallbackPath	콜백 경로 (기본값: /callback)
+// AuthorizeUrl	인증 URL (기본값: TISTORY_OAUTH_AUTHENTICATIONTOKEN_GET_PATH)
+// Open			인증 URL 을 여는 함수 (기본값: Output 에 출력만 한다, 브라우저를 열려면 OpenBrowser)
+// Output		인증 URL 출력 대상 (기본값: os.Stdout)
+type Config struct {
+	// ClientId Tistory Open API 에 블로그 등록시 발급받는 ClientId (필수)
+	ClientId string
+
+	// Port 콜백 서버 포트 (0: 임의 포트, 테스트용)
+	Port int
+
+	// CallbackPath 콜백 경로 (기본값: /callback)
+	CallbackPath string
+
+	// AuthorizeUrl 인증 URL (기본값: TISTORY_OAUTH_AUTHENTICATIONTOKEN_GET_PATH)
+	AuthorizeUrl string
+
+	// Open 인증 URL 을 여는 함수 (기본값: Output 에 출력만 한다, 브라우저를 열려면 OpenBrowser)
+	Open func(authorizeUrl string) error
+
+	// Output 인증 URL 출력 대상 (기본값: os.Stdout)
+	Output io.Writer
+}
+
+// RedirectUrl 콜백 서버의 redirect_uri
+// Port 가 0 이면 실제 포트는 서버를 띄운 뒤에 정해지므로 GetAuthorizeCode 가 반환한 redirectUrl 을 써야 한다.
+func (c Config) RedirectUrl() string {
+	return "http://127.0.0.1:" + strconv.Itoa(c.Port) + c.callbackPath()
+}
+
+func (c Config) callbackPath() string {
+	if c.CallbackPath == "" {
+		return "/callback"
+	}
+	return c.CallbackPath
+}
+
+// callback 콜백 요청 처리 결과
+type callback struct {
+	code string
+	err  error
+}
+
+// GetAuthorizeCode 로컬 콜백 서버로 Authorization Code 구하기
+// ctx 가 취소되거나 state 가 맞는 콜백을 받으면 서버를 종료한다.
+// 받은 code 와 redirectUrl 은 model.UserData 의 AuthorizationCode, RedirectUrl 에 넣어 tistoryAPI.NewService 에 넘기면 된다.
+// redirectUrl 은 인증 요청에 실제로 쓴 redirect_uri 이다. (Port 가 0 이면 서버를 띄운 포트가 들어간다)
+// @Param context.Context Config	// 컨텍스트, 콜백 서버 설정
+// return string, string, error	// code, redirectUrl, 에러
+func GetAuthorizeCode(ctx context.Context, cfg Config) (code, redirectUrl string, err error) {
+
+	if cfg.ClientId == "" {
+		return "", "", errors.New("tistoryAPI/oauth: empty client id")
+	}
+
+	stateToken, err := state.Gen()
+	if err != nil {
+		return "", "", err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:"+strconv.Itoa(cfg.Port))
+	if err != nil {
+		return "", "", err
+	}
+	cfg.Port = listener.Addr().(*net.TCPAddr).Port
+	redirectUrl = cfg.RedirectUrl()
+
+	result := make(chan callback, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(cfg.callbackPath(), func(w http.ResponseWriter, r *http.Request) {
+		cb := parseCallback(r.URL.Query(), stateToken)
+		if cb.err != nil {
+			http.Error(w, "인증 실패: "+cb.err.Error(), http.StatusBadRequest)
+		} else {
+			_, _ = io.WriteString(w, "인증 완료. 창을 닫아도 됩니다.")
+		}
+		if errors.Is(cb.err, ErrStateMismatch) {
+			// 위조되었거나 다른 인증 시도의 요청이므로 무시하고 계속 기다린다.
+			return
+		}
+		select {
+		case result <- cb:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	authorizeUrl := buildAuthorizeUrl(cfg, stateToken)
+	if err := openUrl(cfg, authorizeUrl); err != nil {
+		return "", "", err
+	}
+
+	select {
+	case <-ctx.Done():
+		return "", "", ctx.Err()
+	case cb := <-result:
+		return cb.code, redirectUrl, cb.err
+	}
+}
+
+// buildAuthorizeUrl 인증 URL 생성
+func buildAuthorizeUrl(cfg Config, stateToken string) string {
+	authorizeUrl := cfg.AuthorizeUrl
+	if authorizeUrl == "" {
+		authorizeUrl = tistoryAPI.TISTORY_OAUTH_AUTHENTICATIONTOKEN_GET_PATH
+	}
+
+	query := url.Values{}
+	query.Set("client_id", cfg.ClientId)
+	query.Set("redirect_uri", cfg.RedirectUrl())
+	query.Set("response_type", "code")
+	query.Set("state", stateToken)
+	return authorizeUrl + "?" + query.Encode()
+}
+
+// parseCallback 리다이렉트 쿼리스트링 검증
+// state 를 가장 먼저 검사해서 위조된 요청의 code, error 는 신뢰하지 않는다.
+func parseCallback(query url.Values, stateToken string) callback {
+	if err := state.Verify(stateToken, query.Get("state")); err != nil {
+		return callback{err: err}
+	}
+	if errCode := query.Get("error"); errCode != "" {
+		return callback{err: fmt.Errorf("%w: %s %s", ErrAuthorizationDenied, errCode, query.Get("error_description"))}
+	}
+	code := query.Get("code")
+	if code == "" {
+		return callback{err: ErrNoCode}
+	}
+	return callback{code: code}
+}
+
+func openUrl(cfg Config, authorizeUrl string) error {
+	output := cfg.Output
+	if output == nil {
+		output = os.Stdout
+	}
+	if _, err := fmt.Fprintln(output, "아래 URL 에서 Tistory 인증을 진행해 주세요:\n"+authorizeUrl); err != nil {
+		return err
+	}
+	if cfg.Open == nil {
+		return nil
+	}
+	return cfg.Open(authorizeUrl)
+}
+
+// OpenBrowser 기본 브라우저로 URL 열기 (Config.Open 에 넘겨서 사용)
+func OpenBrowser(target string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", target).Start()
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", target).Start()
+	default:
+		return exec.Command("xdg-open", target).Start()
+	}
+}
@@ -0,0 +1,141 @@
+package oauth
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// newAuthorizeServer redirect 쿼리를 조작할 수 있는 가짜 Tistory 인증 서버
+func newAuthorizeServer(t *testing.T, redirect func(query url.Values) url.Values) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "client", query.Get("client_id"))
+		assert.Equal(t, "code", query.Get("response_type"))
+		assert.NotEmpty(t, query.Get("state"))
+
+		redirectUrl, err := url.Parse(query.Get("redirect_uri"))
+		assert.NoError(t, err)
+		redirectUrl.RawQuery = redirect(query).Encode()
+		http.Redirect(w, r, redirectUrl.String(), http.StatusFound)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// visit 브라우저 대신 인증 URL 을 따라간다.
+func visit(authorizeUrl string) error {
+	resp, err := http.Get(authorizeUrl)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestGetAuthorizeCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		redirect func(query url.Values) url.Values
+		want     string
+		wantErr  error
+	}{
+		{
+			name: "인증 코드 받기:[success]",
+			redirect: func(query url.Values) url.Values {
+				return url.Values{"code": {"auth-code"}, "state": {query.Get("state")}}
+			},
+			want: "auth-code",
+		},
+		{
+			name: "사용자 거부:[failure]",
+			redirect: func(query url.Values) url.Values {
+				return url.Values{"error": {"access_denied"}, "state": {query.Get("state")}}
+			},
+			wantErr: ErrAuthorizationDenied,
+		},
+		{
+			name: "code 누락:[failure]",
+			redirect: func(query url.Values) url.Values {
+				return url.Values{"state": {query.Get("state")}}
+			},
+			wantErr: ErrNoCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newAuthorizeServer(t, tt.redirect)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			var opened string
+			code, redirectUrl, err := GetAuthorizeCode(ctx, Config{
+				ClientId:     "client",
+				AuthorizeUrl: srv.URL,
+				Open: func(authorizeUrl string) error {
+					opened = authorizeUrl
+					return visit(authorizeUrl)
+				},
+				Output: io.Discard,
+			})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, code)
+
+			// Port 가 0 이어도 인증 요청에 실제로 쓴 redirect_uri 를 반환한다.
+			authorizeUrl, err := url.Parse(opened)
+			assert.NoError(t, err)
+			assert.Equal(t, authorizeUrl.Query().Get("redirect_uri"), redirectUrl)
+			assert.NotContains(t, redirectUrl, ":0/")
+		})
+	}
+}
+
+func TestGetAuthorizeCode_StateMismatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// state 가 맞지 않는 콜백은 400 으로 응답하고, 올바른 콜백이 올 때까지 기다린다.
+	code, _, err := GetAuthorizeCode(ctx, Config{
+		ClientId: "client",
+		Open: func(authorizeUrl string) error {
+			u, err := url.Parse(authorizeUrl)
+			if err != nil {
+				return err
+			}
+			query := u.Query()
+			callback := func(values url.Values) int {
+				resp, err := http.Get(query.Get("redirect_uri") + "?" + values.Encode())
+				if !assert.NoError(t, err) {
+					return 0
+				}
+				_ = resp.Body.Close()
+				return resp.StatusCode
+			}
+
+			assert.Equal(t, http.StatusBadRequest, callback(url.Values{"code": {"forged-code"}, "state": {"forged"}}))
+			assert.Equal(t, http.StatusBadRequest, callback(url.Values{"code": {"forged-code"}}))
+			assert.Equal(t, http.StatusOK, callback(url.Values{"code": {"auth-code"}, "state": {query.Get("state")}}))
+			return nil
+		},
+		Output: io.Discard,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "auth-code", code)
+}
+
+func TestGetAuthorizeCode_Cancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, _, err := GetAuthorizeCode(ctx, Config{ClientId: "client", Output: io.Discard})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,131 @@
+package tistoryAPI
+
+import (
+	"github.com/fineroot1253/tistoryAPI/markdown"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Option NewService 설정 옵션
+// 아무 옵션도 주지 않으면 기본 http.Client 와 config.go 의 Tistory URL 을 사용한다.
+type Option func(*options)
+
+type options struct {
+	client    *http.Client
+	apiUrl    string
+	oauthUrl  string
+	userAgent string
+	logger    *slog.Logger
+	timeout   time.Duration
+
+	tokenStore   TokenStore
+	tokenAccount string
+
+	attachCache   AttachmentCache
+	attachRefresh bool
+
+	markdownOpts []markdown.Option
+}
+
+// WithHTTPClient API 요청에 사용할 http.Client 지정 (프록시, Transport 설정등)
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// WithBaseURL Tistory API 기본 URL 지정 (기본값: TISTORY_API_URL)
+// 로컬 테스트 서버를 바라보게 할 때 사용한다.
+func WithBaseURL(apiUrl string) Option {
+	return func(o *options) {
+		o.apiUrl = apiUrl
+	}
+}
+
+// WithOAuthURL Access Token 발급 URL 지정 (기본값: TISTORY_OAUTH_ACCESSTOKEN_GET_PATH)
+func WithOAuthURL(oauthUrl string) Option {
+	return func(o *options) {
+		o.oauthUrl = oauthUrl
+	}
+}
+
+// WithUserAgent 모든 요청에 붙일 User-Agent 지정
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithLogger 요청 로그를 남길 slog.Logger 지정
+// 요청마다 메서드, 경로, 상태코드, 소요시간을 Debug 레벨로 남긴다. access_token 은 남기지 않는다.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithTimeout 요청 타임아웃 지정
+// WithHTTPClient 로 넘긴 클라이언트는 복사해서 적용하므로 원본은 바뀌지 않는다.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithTokenStore NewService 가 사용할 토큰 저장소 지정
+// 저장소에 ClientId + account 키로 토큰이 있으면 Authorization Code 교환 없이 재사용하고,
+// 없으면 새로 발급받은 토큰을 저장한다.
+// @Param TokenStore, string	// 토큰 저장소, 계정 구분 이름
+func WithTokenStore(store TokenStore, account string) Option {
+	return func(o *options) {
+		o.tokenStore = store
+		o.tokenAccount = account
+	}
+}
+
+// WithAttachmentCache 첨부 결과 캐시 지정
+// 파일 내용의 SHA-256 으로 캐시를 먼저 찾고, 있으면 업로드 없이 캐시된 결과(Url, Replacer)를 돌려준다.
+// 글을 다시 발행할 때 같은 이미지가 중복으로 올라가지 않게 하기 위함이다.
+func WithAttachmentCache(cache AttachmentCache) Option {
+	return func(o *options) {
+		o.attachCache = cache
+	}
+}
+
+// WithAttachmentRefresh 첨부 캐시를 읽지 않고 항상 새로 올린 뒤 캐시를 갱신한다.
+// Tistory 에서 파일이 지워지는등 캐시된 Url 을 더 이상 쓸 수 없을 때 사용한다.
+func WithAttachmentRefresh() Option {
+	return func(o *options) {
+		o.attachRefresh = true
+	}
+}
+
+// WithMarkdown WriteMarkdownPost 가 사용할 Markdown 변환 옵션 지정
+// 코드 블록 class 를 하이라이터에 맞게 바꿀 때 사용한다. (ex: markdown.WithCodeClass)
+func WithMarkdown(opts ...markdown.Option) Option {
+	return func(o *options) {
+		o.markdownOpts = append(o.markdownOpts, opts...)
+	}
+}
+
+// newOptions 옵션 적용후 기본값을 채운다.
+func newOptions(opts []Option) options {
+	o := options{
+		apiUrl:   TISTORY_API_URL,
+		oauthUrl: TISTORY_OAUTH_ACCESSTOKEN_GET_PATH,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.client == nil {
+		o.client = &http.Client{}
+	}
+	if o.timeout > 0 {
+		client := *o.client
+		client.Timeout = o.timeout
+		o.client = &client
+	}
+	return o
+}
@@ -0,0 +1,54 @@
+package tistoryAPI
+
+import (
+	"bytes"
+	"context"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewService_Options(t *testing.T) {
+	var userAgents []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.UserAgent())
+		switch r.URL.Path {
+		case "/oauth/access_token":
+			_, _ = w.Write([]byte("access_token=s3cr3t"))
+		case "/apis/blog/info":
+			_, _ = w.Write([]byte(`{"tistory":{"status":"200","item":{"id":"1"}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	logs := &bytes.Buffer{}
+	client := srv.Client()
+
+	serv, err := NewService(context.Background(), model.UserData{AuthorizationCode: "code"},
+		WithHTTPClient(client),
+		WithBaseURL(srv.URL+"/apis"),
+		WithOAuthURL(srv.URL+"/oauth/access_token"),
+		WithUserAgent("tistory-bot/1.0"),
+		WithLogger(slog.New(slog.NewTextHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		WithTimeout(5*time.Second),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t", serv.GetACToken())
+
+	info, err := serv.GetBlogInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", info.Item.Id)
+
+	assert.Equal(t, []string{"tistory-bot/1.0", "tistory-bot/1.0"}, userAgents)
+	assert.Contains(t, logs.String(), "path=/apis/blog/info")
+	assert.NotContains(t, logs.String(), "s3cr3t")
+	// 넘긴 클라이언트는 복사해서 타임아웃을 적용한다.
+	assert.Zero(t, client.Timeout)
+	assert.Equal(t, 5*time.Second, serv.(*service).client.Timeout)
+}
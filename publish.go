@@ -0,0 +1,198 @@
+package tistoryAPI
+
+import (
+	"context"
+	"fmt"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func (s service) PublishWithAssets(ctx context.Context, data model.PostData, baseDir string) (model.PostWriteResult, error) {
+	// 이미지를 올리기 전에 검사해서 어차피 실패할 글에 첨부 파일만 남지 않게 한다.
+	if err := data.Validate(); err != nil {
+		return model.PostWriteResult{}, err
+	}
+
+	// 본문은 토큰 단위로 원본을 그대로 두고 로컬 이미지 img 태그만 바꾼다.
+	// (다시 렌더링하면 이미 있는 치환자의 따옴표등이 엔티티로 바뀌어 깨진다)
+	var (
+		chunks []htmlChunk
+		paths  []string
+		seen   = map[string]bool{}
+	)
+	z := html.NewTokenizer(strings.NewReader(data.Content))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if err := z.Err(); err != io.EOF {
+				return model.PostWriteResult{}, err
+			}
+			break
+		}
+		chunk := htmlChunk{raw: string(z.Raw())}
+		if tt == html.StartTagToken || tt == html.SelfClosingTagToken {
+			if token := z.Token(); token.DataAtom == atom.Img {
+				path, ok, err := localImagePath(attr(token, "src"), baseDir)
+				if err != nil {
+					return model.PostWriteResult{}, err
+				}
+				if ok {
+					chunk.img, chunk.path = &token, path
+					if !seen[path] {
+						seen[path] = true
+						paths = append(paths, path)
+					}
+				}
+			}
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	// 없는 파일이 섞여 있으면 나머지 이미지만 올라가지 않게 먼저 확인한다.
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			return model.PostWriteResult{}, fmt.Errorf("tistoryAPI: post image: %w", err)
+		}
+	}
+
+	if len(paths) > 0 {
+		attached, err := s.AttachMany(ctx, data.BlogName, paths)
+		if err != nil {
+			return model.PostWriteResult{}, fmt.Errorf("tistoryAPI: upload post images: %w", err)
+		}
+		results := make(map[string]model.AttachResult, len(paths))
+		for i, path := range paths {
+			results[path] = attached.Results[i]
+		}
+
+		content := &strings.Builder{}
+		for _, chunk := range chunks {
+			if chunk.img == nil {
+				content.WriteString(chunk.raw)
+				continue
+			}
+			content.WriteString(replaceImage(*chunk.img, results[chunk.path]))
+		}
+		data.Content = content.String()
+	}
+
+	return s.WritePostContext(ctx, data)
+}
+
+func (s service) WriteMarkdownPost(ctx context.Context, blogName string, mdSource []byte) (model.PostWriteResult, error) {
+	data, update, err := s.ParseMarkdownPost(ctx, blogName, mdSource)
+	if err != nil {
+		return model.PostWriteResult{}, err
+	}
+	if update != nil {
+		return model.PostWriteResult{}, &model.FieldError{Field: "post_id", Message: "이미 있는 글입니다. UpdatePostContext 로 수정해야 합니다."}
+	}
+	return s.WritePostContext(ctx, data)
+}
+
+func (s service) ParseMarkdownPost(ctx context.Context, blogName string, mdSource []byte) (model.PostData, *model.PostUpdateData, error) {
+	doc, err := s.markdown.Convert(mdSource)
+	if err != nil {
+		return model.PostData{}, nil, fmt.Errorf("tistoryAPI: convert markdown: %w", err)
+	}
+
+	fm := doc.FrontMatter
+	data := model.PostData{
+		BlogName:   blogName,
+		Title:      doc.Title,
+		Content:    doc.HTML,
+		Visibility: fm.Visibility,
+		Slogan:     fm.Slug,
+		Tag:        strings.Join(fm.Tags, ","),
+		Password:   fm.Password,
+	}
+	// 비밀번호만 적었으면 보호글로 본다.
+	if fm.Password != "" && fm.Visibility == "" {
+		data.Visibility = model.Protected
+	}
+	if !fm.Published.IsZero() {
+		data.Published = strconv.FormatInt(fm.Published.Unix(), 10)
+	}
+	if fm.Category != "" {
+		if data.Category, err = s.ResolveCategory(ctx, blogName, fm.Category); err != nil {
+			return model.PostData{}, nil, err
+		}
+	}
+
+	if fm.PostId == "" {
+		if err := data.Validate(); err != nil {
+			return model.PostData{}, nil, err
+		}
+		return data, nil, nil
+	}
+
+	update := &model.PostUpdateData{PostId: fm.PostId, PostData: data}
+	if err := update.Validate(); err != nil {
+		return model.PostData{}, nil, err
+	}
+	return data, update, nil
+}
+
+// localImagePath 상대 경로 src 를 baseDir 기준 파일 경로로 바꾼다.
+// 스킴이나 호스트가 있는 URL, data URI, '/' 로 시작하는 사이트 절대 경로는 로컬 이미지가 아니다.
+// '..' 등으로 baseDir 밖을 가리키면 ErrImageOutsideBaseDir 를 반환한다. (본문에 적힌 경로로 아무 파일이나 올라가지 않게)
+// URL 로 해석할 수 없는 src 는 로컬 이미지인지 알 수 없으므로 건너뛰지 않고 ErrInvalidImageSrc 를 반환한다.
+func localImagePath(src, baseDir string) (string, bool, error) {
+	if src == "" || strings.HasPrefix(src, "/") {
+		return "", false, nil
+	}
+	u, err := url.Parse(src)
+	if err != nil {
+		return "", false, fmt.Errorf("%w: %q: %v", ErrInvalidImageSrc, src, err)
+	}
+	if u.Scheme != "" || u.Host != "" || u.Path == "" {
+		return "", false, nil
+	}
+	rel := filepath.Clean(filepath.FromSlash(u.Path))
+	if !filepath.IsLocal(rel) {
+		return "", false, fmt.Errorf("%w: %q", ErrImageOutsideBaseDir, src)
+	}
+	return filepath.Join(baseDir, rel), true, nil
+}
+
+// htmlChunk 본문 토큰 하나
+// raw	원본 그대로의 토큰 텍스트
+// img	로컬 이미지 img 태그면 해석한 토큰 (아니면 nil)
+// path	로컬 이미지 파일 경로
+type htmlChunk struct {
+	raw  string
+	img  *html.Token
+	path string
+}
+
+// replaceImage Replacer 가 있으면 img 태그를 Replacer 로 통째로 바꾸고, 없으면 src 만 Url 로 바꾼 태그를 반환한다.
+func replaceImage(img html.Token, result model.AttachResult) string {
+	if result.Replacer != "" {
+		return result.Replacer
+	}
+	attrs := make([]html.Attribute, len(img.Attr))
+	copy(attrs, img.Attr)
+	for i := range attrs {
+		if attrs[i].Key == "src" {
+			attrs[i].Val = result.Url
+		}
+	}
+	img.Attr = attrs
+	return img.String()
+}
+
+func attr(token html.Token, key string) string {
+	for _, a := range token.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,256 @@
+package tistoryAPI
+
+import (
+	"context"
+	"github.com/fineroot1253/tistoryAPI/markdown"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"github.com/fineroot1253/tistoryAPI/tistorytest"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestService_PublishWithAssets(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "secret.txt"), []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	baseDir := filepath.Join(root, "post")
+	if err := os.MkdirAll(filepath.Join(baseDir, "img"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"foo.png", "bar baz.png"} {
+		if err := os.WriteFile(filepath.Join(baseDir, "img", name), []byte("\x89PNG "+name), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		content         string
+		wantAttachments int
+		want            func(t *testing.T, content string, attachments []tistorytest.Attachment)
+		wantErr         error
+	}{
+		{
+			name: "로컬 이미지 치환:[success]",
+			content: `<p>첫 이미지</p><img src="./img/foo.png" alt="foo">` +
+				`<p><img src="img/bar%20baz.png"> 다시 <img src="img/foo.png"></p>` +
+				`<img src="https://example.com/remote.png"><img src="/static/site.png"><img src="data:image/png;base64,AAAA">`,
+			wantAttachments: 2,
+			want: func(t *testing.T, content string, attachments []tistorytest.Attachment) {
+				replacers := map[string]string{}
+				for _, a := range attachments {
+					replacers[a.Name] = a.Result.Replacer
+				}
+				assert.Equal(t, `<p>첫 이미지</p>`+replacers["foo.png"]+
+					`<p>`+replacers["bar baz.png"]+` 다시 `+replacers["foo.png"]+`</p>`+
+					`<img src="https://example.com/remote.png"><img src="/static/site.png"><img src="data:image/png;base64,AAAA">`, content)
+			},
+		},
+		{
+			name: "기존 치환자와 원본 유지:[success]",
+			content: `<p>it's [##_Image|kage@abc/img.png|CDM|1.3|{"originWidth":100}_##]</p>` +
+				`<IMG class='a' SRC="img/foo.png"><br>`,
+			wantAttachments: 1,
+			want: func(t *testing.T, content string, attachments []tistorytest.Attachment) {
+				assert.Equal(t, `<p>it's [##_Image|kage@abc/img.png|CDM|1.3|{"originWidth":100}_##]</p>`+
+					attachments[0].Result.Replacer+`<br>`, content)
+			},
+		},
+		{
+			name:    "로컬 이미지 없음:[success]",
+			content: `<p>이미지 없는 글 & 특수문자</p>`,
+			want: func(t *testing.T, content string, attachments []tistorytest.Attachment) {
+				assert.Equal(t, `<p>이미지 없는 글 & 특수문자</p>`, content)
+			},
+		},
+		{
+			name:    "없는 이미지:[failure]",
+			content: `<img src="img/foo.png"><img src="img/missing.png">`,
+			wantErr: os.ErrNotExist,
+		},
+		{
+			name:    "baseDir 밖의 이미지:[failure]",
+			content: `<img src="img/foo.png"><img src="../secret.txt">`,
+			wantErr: ErrImageOutsideBaseDir,
+		},
+		{
+			name:    "baseDir 밖의 이미지, 중간 경로:[failure]",
+			content: `<img src="img/../../secret.txt">`,
+			wantErr: ErrImageOutsideBaseDir,
+		},
+		{
+			name:    "URL 로 해석할 수 없는 src:[failure]",
+			content: `<img src="img/foo.png"><img src="img/100%.png">`,
+			wantErr: ErrInvalidImageSrc,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serv, srv := newTestService(t)
+
+			data := model.PostData{BlogName: tistorytest.BlogName, Title: "이미지 글", Content: tt.content}
+			got, err := serv.PublishWithAssets(context.Background(), data, baseDir)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, srv.Attachments())
+				_, err := serv.GetPost(tistorytest.BlogName, "1")
+				assert.ErrorIs(t, err, ErrNotFound)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, srv.Attachments(), tt.wantAttachments)
+
+			post, err := serv.GetPost(tistorytest.BlogName, got.PostId)
+			assert.NoError(t, err)
+			tt.want(t, post.Item.Content, srv.Attachments())
+		})
+	}
+}
+
+func TestService_WriteMarkdownPost(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		source  string
+		want    model.PostDetailItem
+		wantErr error
+	}{
+		{
+			name:   "Markdown 글 작성:[success]",
+			source: "# 마크다운 글\n\n| a | b |\n|---|---|\n| 1 | 2 |\n\n```go\nx := 1\n```\n",
+			want: model.PostDetailItem{
+				Title:   "마크다운 글",
+				Content: "<table>\n<thead>\n<tr>\n<th>a</th>\n<th>b</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>1</td>\n<td>2</td>\n</tr>\n</tbody>\n</table>\n<pre><code class=\"language-go\">x := 1\n</code></pre>\n",
+			},
+		},
+		{
+			name:   "코드 블록 클래스 변경:[success]",
+			opts:   []Option{WithMarkdown(markdown.WithCodeClass(func(language string) string { return "lang-" + language }))},
+			source: "# 코드\n\n```go\nx := 1\n```\n",
+			want: model.PostDetailItem{
+				Title:   "코드",
+				Content: "<pre><code class=\"lang-go\">x := 1\n</code></pre>\n",
+			},
+		},
+		{
+			name:    "제목 없음:[failure]",
+			source:  "본문만 있는 글\n",
+			wantErr: model.ErrInvalidData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serv, _ := newTestService(t, tt.opts...)
+
+			got, err := serv.WriteMarkdownPost(context.Background(), tistorytest.BlogName, []byte(tt.source))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			post, err := serv.GetPost(tistorytest.BlogName, got.PostId)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.Title, post.Item.Title)
+			assert.Equal(t, tt.want.Content, post.Item.Content)
+		})
+	}
+}
+
+func TestService_ParseMarkdownPost(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     string
+		want       model.PostData
+		wantPostId string
+		wantErr    error
+	}{
+		{
+			name: "새 글:[success]",
+			source: "---\ntitle: 제목\ntags: [go, tistory]\ncategory: Dev/Go\nvisibility: public\n" +
+				"published: 2024-01-02 09:00:00\nslug: hello\n---\n본문\n",
+			want: model.PostData{
+				BlogName: tistorytest.BlogName, Title: "제목", Content: "<p>본문</p>\n", Visibility: model.Public,
+				Category: "go", Published: "1704153600", Slogan: "hello", Tag: "go,tistory",
+			},
+		},
+		{
+			name:   "글 수정, 비밀번호만 있는 보호글:[success]",
+			source: "+++\npostId = 12\npassword = \"pw\"\n+++\n# 본문 제목\n본문\n",
+			want: model.PostData{
+				BlogName: tistorytest.BlogName, Title: "본문 제목", Content: "<p>본문</p>\n",
+				Visibility: model.Protected, Password: "pw",
+			},
+			wantPostId: "12",
+		},
+		{
+			name:   "카테고리 이름:[success]",
+			source: "---\ntitle: 제목\ncategory: Go\n---\n본문\n",
+			want: model.PostData{
+				BlogName: tistorytest.BlogName, Title: "제목", Content: "<p>본문</p>\n", Category: "go",
+			},
+		},
+		{
+			name:    "같은 이름 카테고리 여러 개:[failure]",
+			source:  "---\ntitle: 제목\ncategory: Notes\n---\n",
+			wantErr: ErrCategoryAmbiguous,
+		},
+		{
+			name:    "없는 카테고리:[failure]",
+			source:  "---\ntitle: 제목\ncategory: Dev/Rust\n---\n",
+			wantErr: ErrCategoryNotFound,
+		},
+		{
+			name:    "제목 없음:[failure]",
+			source:  "---\ntags: go\n---\n본문\n",
+			wantErr: model.ErrInvalidData,
+		},
+		{
+			name:    "잘못된 front matter:[failure]",
+			source:  "---\nvisibility: hidden\n---\n",
+			wantErr: markdown.ErrInvalidFrontMatter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serv, srv := newTestService(t)
+			dev := srv.AddCategory("Dev", "")
+			goId := srv.AddCategory("Go", dev)
+			srv.AddCategory("Notes", dev)
+			srv.AddCategory("Notes", goId)
+			if tt.want.Category != "" {
+				tt.want.Category = goId
+			}
+
+			got, update, err := serv.ParseMarkdownPost(context.Background(), tistorytest.BlogName, []byte(tt.source))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			if tt.wantPostId == "" {
+				assert.Nil(t, update)
+				return
+			}
+			assert.Equal(t, &model.PostUpdateData{PostId: tt.wantPostId, PostData: tt.want}, update)
+		})
+	}
+}
+
+func TestService_WriteMarkdownPost_FrontMatter(t *testing.T) {
+	serv, _ := newTestService(t)
+
+	got, err := serv.WriteMarkdownPost(context.Background(), tistorytest.BlogName, []byte("---\ntitle: 제목\ntags: go, tistory\n---\n본문\n"))
+	assert.NoError(t, err)
+	post, err := serv.GetPost(tistorytest.BlogName, got.PostId)
+	assert.NoError(t, err)
+	assert.Equal(t, "제목", post.Item.Title)
+	assert.Equal(t, []string{"go", "tistory"}, post.Item.Tags.Tag)
+
+	_, err = serv.WriteMarkdownPost(context.Background(), tistorytest.BlogName, []byte("---\ntitle: 제목\npostId: 1\n---\n"))
+	assert.ErrorIs(t, err, model.ErrInvalidData)
+}
@@ -0,0 +1,102 @@
+package tistoryAPI
+
+import (
+	"context"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// params API 요청 파라미터 빌더
+// 모든 값은 url.Values 를 통해 인코딩 되므로 '&', '#', '+', 한글, HTML 이 그대로 전달된다.
+type params url.Values
+
+// newParams 필수 파라미터로 blogName 을 가진 빌더를 생성한다.
+func newParams(blogName string) params {
+	p := params{}
+	p.set("blogName", blogName)
+	return p
+}
+
+// set 값이 빈 문자열이면 파라미터를 보내지 않는다.
+// 선택 파라미터를 빈 값으로 보내 Tistory 기본값을 덮어쓰지 않기 위함이다.
+func (p params) set(key, value string) params {
+	if value != "" {
+		url.Values(p).Set(key, value)
+	}
+	return p
+}
+
+// endpoint API 경로에 쿼리스트링을 붙인 전체 URL
+func (s service) endpoint(path string, query url.Values) string {
+	return s.apiUrl + path + "?" + query.Encode()
+}
+
+// newRequest API 요청 생성
+// GET 요청은 쿼리스트링으로, 그 외 요청은 application/x-www-form-urlencoded 바디로 파라미터를 보낸다.
+// access_token, output 파라미터는 여기서 채운다.
+// 요청은 ctx 에 묶이므로 ctx 취소, 타임아웃시 바로 중단된다.
+func (s service) newRequest(ctx context.Context, method, path string, p params) (*http.Request, error) {
+
+	values := url.Values{}
+	for key, value := range p {
+		values[key] = value
+	}
+	values.Set("access_token", s.token.AccessToken)
+	values.Set("output", "json")
+
+	if method == http.MethodGet {
+		return http.NewRequestWithContext(ctx, method, s.endpoint(path, values), nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.apiUrl+path, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req, nil
+}
+
+// call API 요청을 보내고 결과를 T 로 파싱한다.
+func call[T model.Tistory[PI, CI], PI model.PostItemModel, CI model.CommentItemModel](ctx context.Context, s service, method, path string, p params) (T, error) {
+
+	var result T
+
+	req, err := s.newRequest(ctx, method, path, p)
+	if err != nil {
+		return result, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return result, err
+	}
+
+	if err := compute[T, PI, CI](&result, resp); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// do 공통 헤더를 붙여 요청을 보내고 로그를 남긴다.
+func (s service) do(req *http.Request) (*http.Response, error) {
+
+	if s.userAgent != "" {
+		req.Header.Set("User-Agent", s.userAgent)
+	}
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+
+	if s.logger != nil {
+		attrs := []any{"method", req.Method, "path", req.URL.Path, "elapsed", time.Since(start)}
+		if err != nil {
+			s.logger.DebugContext(req.Context(), "tistoryAPI request failed", append(attrs, "error", err)...)
+		} else {
+			s.logger.DebugContext(req.Context(), "tistoryAPI request", append(attrs, "status", resp.StatusCode)...)
+		}
+	}
+	return resp, err
+}
@@ -0,0 +1,139 @@
+package tistoryAPI
+
+import (
+	"context"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// newRecordService 받은 요청을 기록하는 테스트 서버와 그 서버를 바라보는 서비스를 만든다.
+func newRecordService(t *testing.T, body string) (*service, *[]*http.Request, *[]url.Values) {
+	var requests []*http.Request
+	var forms []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		requests = append(requests, r)
+		forms = append(forms, r.Form)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	return &service{client: srv.Client(), ctx: context.Background(), apiUrl: srv.URL + "/apis", token: model.Token{AccessToken: "token&="}}, &requests, &forms
+}
+
+func TestRequest_Write(t *testing.T) {
+	content := `<p class="a&b">안녕하세요 #태그 1+1=2 &amp; 100%</p>`
+	tests := []struct {
+		name   string
+		send   func(s *service) error
+		path   string
+		fields map[string]string
+	}{
+		{
+			name: "글쓰기 인코딩 테스트:[success]",
+			send: func(s *service) error {
+				_, err := s.WritePost(model.PostData{BlogName: "blog", Title: "제목 & 부제", Content: content, Tag: "go,한글"})
+				return err
+			},
+			path:   "/apis/post/write",
+			fields: map[string]string{"blogName": "blog", "title": "제목 & 부제", "content": content, "tag": "go,한글"},
+		},
+		{
+			name: "글 수정 인코딩 테스트:[success]",
+			send: func(s *service) error {
+				_, err := s.UpdatePost(model.PostUpdateData{PostId: "7", PostData: model.PostData{BlogName: "blog", Title: "수정", Content: content}})
+				return err
+			},
+			path:   "/apis/post/modify",
+			fields: map[string]string{"postId": "7", "title": "수정", "content": content},
+		},
+		{
+			name: "댓글 쓰기 인코딩 테스트:[success]",
+			send: func(s *service) error {
+				_, err := s.WriteComment(model.CommentData{BlogName: "blog", PostId: "7", Content: content})
+				return err
+			},
+			path:   "/apis/comment/write",
+			fields: map[string]string{"postId": "7", "content": content},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, requests, forms := newRecordService(t, `{"tistory":{"status":"200"}}`)
+
+			assert.NoError(t, tt.send(s))
+			assert.Len(t, *requests, 1)
+
+			req := (*requests)[0]
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, tt.path, req.URL.Path)
+			assert.Equal(t, "application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+			assert.Empty(t, req.URL.RawQuery)
+
+			form := (*forms)[0]
+			assert.Equal(t, "token&=", form.Get("access_token"))
+			assert.Equal(t, "json", form.Get("output"))
+			for key, want := range tt.fields {
+				assert.Equal(t, want, form.Get(key), key)
+			}
+			// 빈 선택 파라미터는 보내지 않는다.
+			assert.NotContains(t, form, "password")
+		})
+	}
+}
+
+func TestRequest_Read(t *testing.T) {
+	s, requests, _ := newRecordService(t, `{"tistory":{"status":"200","item":{"page":"2"}}}`)
+
+	got, err := s.GetPostList("블로그&name", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, model.FlexInt(2), got.Item.Page)
+
+	req := (*requests)[0]
+	assert.Equal(t, http.MethodGet, req.Method)
+	assert.Equal(t, "/apis/post/list", req.URL.Path)
+	query := req.URL.Query()
+	assert.Equal(t, "블로그&name", query.Get("blogName"))
+	assert.Equal(t, "2", query.Get("page"))
+	assert.Equal(t, "token&=", query.Get("access_token"))
+}
+
+func TestRequest_Context(t *testing.T) {
+	s, requests, _ := newRecordService(t, `{"tistory":{"status":"200"}}`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := s.GetPostListContext(ctx, "blog", 1)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = s.WritePostContext(ctx, model.PostData{BlogName: "blog", Title: "제목"})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, *requests)
+
+	// ctx 가 없는 버전은 서비스 생성시 ctx 를 사용한다.
+	s.ctx = ctx
+	_, err = s.GetBlogInfo()
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRequest_Validate(t *testing.T) {
+	s, requests, _ := newRecordService(t, `{"tistory":{"status":"200"}}`)
+
+	_, err := s.WritePost(model.PostData{BlogName: "blog", Password: "1234"})
+	assert.ErrorIs(t, err, model.ErrInvalidData)
+	_, err = s.UpdatePost(model.PostUpdateData{PostData: model.PostData{BlogName: "blog", Title: "제목"}})
+	assert.ErrorIs(t, err, model.ErrInvalidData)
+	_, err = s.WriteComment(model.CommentData{BlogName: "blog", PostId: "7"})
+	assert.ErrorIs(t, err, model.ErrInvalidData)
+	_, err = s.UpdateComment(model.CommentUpdateData{CommentData: model.CommentData{BlogName: "blog", PostId: "7", Content: "댓글"}})
+	assert.ErrorIs(t, err, model.ErrInvalidData)
+
+	// 검증에 실패하면 요청을 보내지 않는다.
+	assert.Empty(t, *requests)
+}
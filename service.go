@@ -1,402 +1,578 @@
 package tistoryAPI
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/fineroot1253/tistoryAPI/markdown"
 	"github.com/fineroot1253/tistoryAPI/model"
+	"golang.org/x/sync/singleflight"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // Service TistoryAPI 인터페이스
 // 초기화 조건 : Selenium 설치후 authorization code를 받아야한다.
 // 블로그 명 : 블로그 URL의 'xxx.tistory.com' xxx 부분을 의미 한다.
+// 모든 메서드는 ctx 를 받는 ...Context 버전을 가지며, ctx 가 없는 버전은 NewService 에 넘긴 ctx 를 사용한다.
+// 실패 응답은 *APIError 로 반환되며 errors.Is(err, ErrNotFound) 처럼 종류를 판별할 수 있다.
+// 글, 댓글 쓰기/수정은 요청 전에 Validate 로 검사하며, 실패하면 요청 없이 model.ErrInvalidData 를 반환한다.
 type Service interface {
 
 	// WritePost 글 작성하기
 	// @Param model.PostData
 	// return model.PostWriteResult, error
 	WritePost(data model.PostData) (model.PostWriteResult, error)
+	// WritePostContext WritePost 의 컨텍스트 버전
+	WritePostContext(ctx context.Context, data model.PostData) (model.PostWriteResult, error)
+	// PublishWithAssets 본문의 로컬 이미지를 첨부한 뒤 글 작성하기
+	// Content 의 <img src="./img/foo.png"> 처럼 상대 경로인 이미지를 baseDir 기준으로 찾아 올리고,
+	// img 태그를 첨부 결과의 Replacer 로 (Replacer 가 없으면 src 를 Url 로) 바꾼 뒤 WritePost 를 호출한다.
+	// 이미지가 하나라도 실패하면 글을 쓰지 않는다. 바꾼 img 태그 외의 본문은 원본 그대로 둔다.
+	// '../' 처럼 baseDir 밖을 가리키는 이미지가 있으면 아무것도 올리지 않고 ErrImageOutsideBaseDir 를 반환한다.
+	// src 를 URL 로 해석할 수 없거나 (ErrInvalidImageSrc) 없는 파일이 있어도 아무것도 올리지 않는다.
+	// @Param context.Context model.PostData string	// 컨텍스트, 글 데이터, 이미지 기준 디렉터리
+	// return model.PostWriteResult, error
+	PublishWithAssets(ctx context.Context, data model.PostData, baseDir string) (model.PostWriteResult, error)
+	// WriteMarkdownPost Markdown 문서를 HTML 로 변환해서 글 작성하기
+	// 글 데이터는 ParseMarkdownPost 와 같이 만든다. front matter 에 postId 가 있으면 model.ErrInvalidData 를 반환한다.
+	// 변환 옵션은 WithMarkdown 으로 지정한다.
+	// @Param context.Context string []byte	// 컨텍스트, 블로그 명, Markdown 원문
+	// return model.PostWriteResult, error
+	WriteMarkdownPost(ctx context.Context, blogName string, mdSource []byte) (model.PostWriteResult, error)
+	// ParseMarkdownPost front matter 가 있는 Markdown 문서로 글 쓰기/수정 DTO 만들기
+	// 제목은 front matter 의 title, 없으면 본문 맨 앞의 '# 제목' 을 쓴다.
+	// category 는 GetCategoryList 로 찾은 카테고리 ID 로 바꾸고, 없으면 ErrCategoryNotFound 를 반환한다.
+	// front matter 에 postId 가 없으면 update 는 nil 이고 data 로 WritePost 를 호출하면 된다.
+	// postId 가 있으면 update 에 글 수정 DTO 를 담아 반환하며 (update.PostData 는 data 와 같다), UpdatePost 를 호출하면 된다.
+	// @Param context.Context string []byte	// 컨텍스트, 블로그 명, Markdown 원문
+	// return model.PostData, *model.PostUpdateData, error	// 글 데이터, 글 수정 데이터 (postId 가 없으면 nil)
+	ParseMarkdownPost(ctx context.Context, blogName string, mdSource []byte) (data model.PostData, update *model.PostUpdateData, err error)
 	// WriteComment 댓글 작성하기
 	// @Param model.CommentData
 	// return model.CommentWriteResult, error
 	WriteComment(data model.CommentData) (model.CommentWriteResult, error)
+	// WriteCommentContext WriteComment 의 컨텍스트 버전
+	WriteCommentContext(ctx context.Context, data model.CommentData) (model.CommentWriteResult, error)
 	// AttachFiles 파일 첨부하기
 	// @Param string	// 블로그 명
 	// return model.AttachResult, error
 	AttachFiles(blogName string, filePath string) (model.AttachResult, error)
+	// AttachFilesContext AttachFiles 의 컨텍스트 버전
+	AttachFilesContext(ctx context.Context, blogName string, filePath string) (model.AttachResult, error)
+	// AttachReader r 의 내용을 filename 이름의 파일로 첨부하기
+	// 디스크를 거치지 않고 스트리밍으로 업로드한다. (생성한 이미지 업로드등)
+	// WithAttachmentCache 를 쓰는 경우 r 이 io.Seeker 가 아니면 해시를 구하기 위해 내용을 메모리에 담는다.
+	// @Param context.Context string string io.Reader	// 컨텍스트, 블로그 명, 파일 이름, 파일 내용
+	// return model.AttachResult, error
+	AttachReader(ctx context.Context, blogName, filename string, r io.Reader) (model.AttachResult, error)
+	// AttachMany 여러 파일을 동시에 첨부하기
+	// 결과는 paths 와 같은 순서로 돌려주며, 일부가 실패해도 성공한 파일의 결과는 채워진다.
+	// 하나라도 실패하면 실패한 파일들의 에러를 errors.Join 으로 묶어 반환한다. (파일별 에러는 paths 인덱스를 key 로 AttachManyResult.Errors 에)
+	// @Param context.Context string []string ...AttachOption	// 컨텍스트, 블로그 명, 파일 경로 목록, 동시 업로드 수/진행 상황 콜백
+	// return AttachManyResult, error
+	AttachMany(ctx context.Context, blogName string, paths []string, opts ...AttachOption) (AttachManyResult, error)
 
 	// GetBlogInfo 블로그 정보 가져오기
 	// return model.BlogResult, error
 	GetBlogInfo() (model.BlogResult, error)
+	// GetBlogInfoContext GetBlogInfo 의 컨텍스트 버전
+	GetBlogInfoContext(ctx context.Context) (model.BlogResult, error)
 
 	// GetPostList 글 목록 가져오기
 	// @Param string int	// 블로그 명, 글 목록 페이지 넘버
 	// return model.PostResult[model.PostListItem], error
 	GetPostList(blogName string, pageNumber int) (model.PostResult[model.PostListItem], error)
+	// GetPostListContext GetPostList 의 컨텍스트 버전
+	GetPostListContext(ctx context.Context, blogName string, pageNumber int) (model.PostResult[model.PostListItem], error)
+	// PostIter 모든 페이지의 글 목록을 차례로 순회하는 iterator
+	// 페이지는 필요할 때 요청하며, 마지막 페이지에서 멈춘다.
+	// @Param context.Context string ...PostIterOption	// 컨텍스트, 블로그 명, 카테고리/공개 여부 필터
+	// return *Iterator[model.PostListItemData]
+	PostIter(ctx context.Context, blogName string, opts ...PostIterOption) *Iterator[model.PostListItemData]
 	// GetPost 글 상세 데이터 가져오기
 	// @Param string	// 블로그 명
 	// return model.PostResult[model.PostDetailItem], error
 	GetPost(blogName, postId string) (model.PostResult[model.PostDetailItem], error)
+	// GetPostContext GetPost 의 컨텍스트 버전
+	GetPostContext(ctx context.Context, blogName, postId string) (model.PostResult[model.PostDetailItem], error)
 	// GetNewestCommentList 최신 댓글 목록 가져오기
 	// @Param string, int, int	// 블로그 명, 댓글 목록 페이지 넘버, 댓글 페이지당 댓글 수 (기본=10, 최대=10)
 	// return model.CommentResult[model.CommentNewestListItem], error
 	GetNewestCommentList(blogName string, pageNumber int, count int) (model.CommentResult[model.CommentNewestListItem], error)
+	// GetNewestCommentListContext GetNewestCommentList 의 컨텍스트 버전
+	GetNewestCommentListContext(ctx context.Context, blogName string, pageNumber int, count int) (model.CommentResult[model.CommentNewestListItem], error)
+	// NewestComments since 이후에 작성된 최신 댓글을 최신순으로 순회하는 iterator
+	// since 이전(같은 시각 포함) 댓글을 만나면 멈추므로, 지난 실행 시각을 넘기면 새 댓글만 처리할 수 있다.
+	// since 가 zero 값이면 모든 댓글을 순회한다.
+	// @Param context.Context string time.Time	// 컨텍스트, 블로그 명, 기준 시각
+	// return *Iterator[model.CommentNewestItemData]
+	NewestComments(ctx context.Context, blogName string, since time.Time) *Iterator[model.CommentNewestItemData]
 	// GetCommentList 댓글 목록 가져오기
 	// @Param model.CommentData
 	// return model.CommentResult[model.CommentListItem], error
 	GetCommentList(blogName, postId string) (model.CommentResult[model.CommentListItem], error)
+	// GetCommentListContext GetCommentList 의 컨텍스트 버전
+	GetCommentListContext(ctx context.Context, blogName, postId string) (model.CommentResult[model.CommentListItem], error)
 	// GetCategoryList 카테고리 목록 가져오기
 	// @Param string	//블로그 명
 	// return model.CategoryResult, error
 	GetCategoryList(blogName string) (model.CategoryResult, error)
+	// GetCategoryListContext GetCategoryList 의 컨텍스트 버전
+	GetCategoryListContext(ctx context.Context, blogName string) (model.CategoryResult, error)
+	// ResolveCategory "Dev/Go" 같은 카테고리 경로나 "Go" 같은 이름을 카테고리 ID 로 바꾸기
+	// '/' 가 없는 값은 최상위 카테고리를 먼저 찾고, 없으면 트리 전체에서 이름이 같은 카테고리를 찾는다.
+	// 없으면 ErrCategoryNotFound, 이름이 같은 카테고리가 여럿이면 ErrCategoryAmbiguous 를 반환한다. (이때는 경로로 지정한다)
+	// @Param context.Context string string	// 컨텍스트, 블로그 명, 카테고리 경로 또는 이름
+	// return string, error
+	ResolveCategory(ctx context.Context, blogName, path string) (string, error)
 
 	// UpdatePost 글 수정하기
 	// @Param model.PostUpdateData
 	// return model.PostWriteResult, error
 	UpdatePost(data model.PostUpdateData) (model.PostWriteResult, error)
+	// UpdatePostContext UpdatePost 의 컨텍스트 버전
+	UpdatePostContext(ctx context.Context, data model.PostUpdateData) (model.PostWriteResult, error)
+	// PatchPost 글 부분 수정하기
+	// 현재 글을 GetPost 로 읽고 patch 에 설정된 필드만 덮어써서 UpdatePost 로 보낸다.
+	// 수정 전, 수정 후(다시 읽은) 글을 함께 반환하므로 변경 기록을 남길 수 있다.
+	// 상세 API 가 비밀번호를 내려주지 않으므로, 보호글을 보호글 그대로 수정할 때는 patch.Password 가 필수다.
+	// 없으면 비밀번호가 지워지지 않게 요청 없이 model.ErrInvalidData (*model.FieldError) 를 반환한다.
+	// @Param context.Context string string model.PostPatch	// 컨텍스트, 블로그 명, 포스트 ID, 수정할 필드
+	// return model.PostDetailItem, model.PostDetailItem, error	// 수정 전, 수정 후
+	PatchPost(ctx context.Context, blogName, postId string, patch model.PostPatch) (before, after model.PostDetailItem, err error)
 	// UpdateComment 댓글 수정하기
 	// @Param model.CommentUpdateData
 	// return model.CommentWriteResult, error
 	UpdateComment(data model.CommentUpdateData) (model.CommentWriteResult, error)
+	// UpdateCommentContext UpdateComment 의 컨텍스트 버전
+	UpdateCommentContext(ctx context.Context, data model.CommentUpdateData) (model.CommentWriteResult, error)
 
 	// DeleteComment 댓글 삭제하기
 	// @Param uint64, uint64
 	// return model.CommentDeleteResult, error
 	DeleteComment(blogName string, postId string, commentId string) (model.CommentDeleteResult, error)
+	// DeleteCommentContext DeleteComment 의 컨텍스트 버전
+	DeleteCommentContext(ctx context.Context, blogName string, postId string, commentId string) (model.CommentDeleteResult, error)
 
 	// GetACToken 엑세스 토큰 확인
 	// return string
 	GetACToken() string
+	// Token 엑세스 토큰 정보 확인 (발급 시간, 발급 응답 원문 포함)
+	// return model.Token
+	Token() model.Token
 }
 
 type service struct {
 	// 각종 API에 사용되는 클라이언트
-	client http.Client
+	client *http.Client
 	ctx    context.Context
 
-	accessToken string
+	// Tistory API 기본 URL
+	apiUrl string
+	// 요청마다 붙일 User-Agent (비어있으면 http.Client 기본값)
+	userAgent string
+	// 요청 로그용 로거 (nil 이면 로그를 남기지 않는다)
+	logger *slog.Logger
+
+	// 첨부 결과 캐시 (nil 이면 캐시하지 않는다)
+	attachCache AttachmentCache
+	// true 면 첨부 캐시를 읽지 않고 항상 새로 올린다.
+	attachRefresh bool
+	// 같은 AttachmentKey 를 동시에 올리면 한번만 올리고 결과를 나눠 쓴다.
+	attachGroup *singleflight.Group
+
+	// WriteMarkdownPost 용 Markdown 변환기
+	markdown *markdown.Converter
+
+	// 엑세스 토큰
+	token model.Token
 }
 
 // NewService Tistory API 생성함수
 // 생성시 http 통신을 통해 Access Token을 받고 세팅한다.
+// WithTokenStore 옵션이 있으면 저장된 토큰을 먼저 사용하고, 새로 받은 토큰은 저장소에 저장한다.
+// 저장에 실패하면 Authorization Code 는 이미 쓰였으므로 토큰이 세팅된 Service 와 저장 에러를 함께 반환한다.
 // 이 생성 과정중 에러 발생 가능성이 가장 높으므로 잘 테스트 해보고 사용 할 것
-// @Params context.Context model.UserData	// 컨텍스트, Tistory Open API 유저 데이터
+// @Params context.Context model.UserData ...Option	// 컨텍스트, Tistory Open API 유저 데이터, 설정 옵션
 // return Service, error
-func NewService(ctx context.Context, userData model.UserData) (Service, error) {
-
-	getAccessTokenPath := TISTORY_OAUTH_ACCESSTOKEN_GET_PATH +
-		"?client_id=" + userData.ClientId +
-		"&client_secret=" + userData.SecretKey +
-		"&redirect_uri=" + userData.RedirectUrl +
-		"&code=" + userData.AuthorizationCode +
-		"&grant_type=authorization_code"
+func NewService(ctx context.Context, userData model.UserData, opts ...Option) (Service, error) {
 
-	client := http.Client{}
+	o := newOptions(opts)
+	s := newService(ctx, o)
 
-	reqWithCtx, err := http.NewRequestWithContext(ctx, http.MethodGet, getAccessTokenPath, nil)
-	if err != nil {
-		return nil, err
+	key := TokenKey{ClientId: userData.ClientId, Account: o.tokenAccount}
+	if o.tokenStore != nil {
+		token, err := o.tokenStore.Load(key)
+		if err == nil {
+			s.token = token
+			return s, nil
+		}
+		if !errors.Is(err, ErrTokenNotFound) {
+			return nil, err
+		}
 	}
 
-	resp, err := client.Do(reqWithCtx)
+	token, err := s.exchangeToken(ctx, o.oauthUrl, userData)
 	if err != nil {
 		return nil, err
 	}
 
-	defer resp.Body.Close()
-
-	all, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	s.token = token
+	if o.tokenStore != nil {
+		if err := o.tokenStore.Save(key, token); err != nil {
+			if s.logger != nil {
+				s.logger.WarnContext(ctx, "tistoryAPI save token failed", "account", o.tokenAccount, "error", err)
+			}
+			return s, fmt.Errorf("tistoryAPI: save token: %w", err)
+		}
 	}
+	return s, nil
+}
+
+// newService 옵션으로 토큰이 없는 서비스를 만든다.
+func newService(ctx context.Context, o options) *service {
+	return &service{
+		client:    o.client,
+		ctx:       ctx,
+		apiUrl:    o.apiUrl,
+		userAgent: o.userAgent,
+		logger:    o.logger,
 
-	return &service{client, ctx, string(all)}, nil
+		attachCache:   o.attachCache,
+		attachRefresh: o.attachRefresh,
+		attachGroup:   &singleflight.Group{},
 
+		markdown: markdown.New(o.markdownOpts...),
+	}
 }
 
 func (s service) WritePost(data model.PostData) (model.PostWriteResult, error) {
+	return s.WritePostContext(s.ctx, data)
+}
 
-	// SET Result Record and Input Record
-	result := model.TistoryResult[model.PostWriteResult, model.EmptyType, model.EmptyType]{Tistory: model.PostWriteResult{}}
-
-	sendUrl := TISTORY_API_URL + "/post/write" +
-		"?access_token=" + s.accessToken +
-		"&output=json" +
-		"&blogName=" + data.BlogName +
-		"&title=" + data.Title +
-		"&content=" + data.Content +
-		"&visibility=" + data.Visibility +
-		"&category=" + data.Category +
-		"&published=" + data.Published +
-		"&slogan=" + data.Slogan +
-		"&tag=" + data.Tag +
-		"&acceptComment=" + data.AcceptComment +
-		"&password=" + data.Password
-
-	resp, err := s.client.Post(sendUrl, "application/json", http.NoBody)
-	if err != nil {
-		return result.Tistory, err
-	}
-
-	if err := compute[model.PostWriteResult, model.EmptyType, model.EmptyType](&result.Tistory, resp); err != nil {
-		return result.Tistory, err
-	}
-	return result.Tistory, nil
+func (s service) WritePostContext(ctx context.Context, data model.PostData) (model.PostWriteResult, error) {
+	if err := data.Validate(); err != nil {
+		return model.PostWriteResult{}, err
+	}
+	p := newParams(data.BlogName).
+		set("title", data.Title).
+		set("content", data.Content).
+		set("visibility", data.Visibility.String()).
+		set("category", data.Category).
+		set("published", data.Published).
+		set("slogan", data.Slogan).
+		set("tag", data.Tag).
+		set("acceptComment", data.AcceptComment.String()).
+		set("password", data.Password)
+	return call[model.PostWriteResult, model.EmptyType, model.EmptyType](ctx, s, http.MethodPost, "/post/write", p)
 }
 
 func (s service) WriteComment(data model.CommentData) (model.CommentWriteResult, error) {
-	// SET Result Record and Input Record
-	result := model.TistoryResult[model.CommentWriteResult, model.EmptyType, model.EmptyType]{Tistory: model.CommentWriteResult{}}
-	sendUrl := TISTORY_API_URL + "/post/write" +
-		"?access_token=" + s.accessToken +
-		"&output=json" +
-		"&blogName=" + data.BlogName +
-		"&postId=" + data.PostId +
-		"&parentId=" + data.ParentId +
-		"&content=" + data.Content +
-		"&secret=" + data.Secret
-
-	resp, err := s.client.Post(sendUrl, "application/json", http.NoBody)
-	if err != nil {
-		return result.Tistory, err
-	}
+	return s.WriteCommentContext(s.ctx, data)
+}
 
-	if err := compute[model.CommentWriteResult, model.EmptyType, model.EmptyType](&result.Tistory, resp); err != nil {
-		return result.Tistory, err
+func (s service) WriteCommentContext(ctx context.Context, data model.CommentData) (model.CommentWriteResult, error) {
+	if err := data.Validate(); err != nil {
+		return model.CommentWriteResult{}, err
 	}
-	return result.Tistory, nil
+	p := newParams(data.BlogName).
+		set("postId", data.PostId).
+		set("parentId", data.ParentId).
+		set("content", data.Content).
+		set("secret", data.Secret.String())
+	return call[model.CommentWriteResult, model.EmptyType, model.EmptyType](ctx, s, http.MethodPost, "/comment/write", p)
 }
 
 func (s service) AttachFiles(blogName string, filePath string) (model.AttachResult, error) {
+	return s.AttachFilesContext(s.ctx, blogName, filePath)
+}
 
-	result := model.TistoryResult[model.AttachResult, model.EmptyType, model.EmptyType]{Tistory: model.AttachResult{}}
-
-	sendUrl := TISTORY_API_URL + "/post/attach" +
-		"?access_token=" + s.accessToken +
-		"&blogName=" + blogName
-
-	// file stream open
+func (s service) AttachFilesContext(ctx context.Context, blogName string, filePath string) (model.AttachResult, error) {
 	openedFile, err := os.Open(filePath)
 	if err != nil {
-		return result.Tistory, nil
+		return model.AttachResult{}, err
 	}
+	defer openedFile.Close()
 
-	// multipart form 생성
-	// writer에 body 버퍼 참조
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	multiPartFile, err := writer.CreateFormFile("uploadedfile", openedFile.Name())
-	if err != nil {
-		return result.Tistory, nil
+	return s.AttachReader(ctx, blogName, filepath.Base(filePath), openedFile)
+}
+
+func (s service) AttachReader(ctx context.Context, blogName, filename string, r io.Reader) (model.AttachResult, error) {
+	return s.attachCached(ctx, blogName, filename, r, nil)
+}
+
+// attachCached 첨부 캐시를 먼저 찾고, 없으면 올린 뒤 캐시에 저장한다.
+// wrap 은 실제로 업로드할 때만 reader 를 감싼다. (진행 상황 표시용, nil 이면 그대로)
+func (s service) attachCached(ctx context.Context, blogName, filename string, r io.Reader, wrap func(io.Reader) io.Reader) (model.AttachResult, error) {
+	if wrap == nil {
+		wrap = func(r io.Reader) io.Reader { return r }
 	}
-	if _, err := io.Copy(multiPartFile, openedFile); err != nil {
-		return result.Tistory, nil
+	if s.attachCache == nil {
+		return s.upload(ctx, blogName, filename, wrap(r))
 	}
 
-	// file stream close
-	if err := writer.Close(); err != nil {
-		return result.Tistory, nil
+	hash, body, err := hashContent(r)
+	if err != nil {
+		return model.AttachResult{}, err
+	}
+	key := AttachmentKey{BlogName: blogName, Hash: hash}
+
+	// 같은 내용을 동시에 올리는 경우 (AttachMany 에 같은 파일이 여러 번 들어온 경우 등) 한번만 올린다.
+	shared, err, _ := s.attachGroup.Do(key.String(), func() (any, error) {
+		if !s.attachRefresh {
+			cached, err := s.attachCache.Get(key)
+			if err == nil {
+				return cached, nil
+			}
+			if !errors.Is(err, ErrAttachmentNotFound) {
+				return model.AttachResult{}, err
+			}
+		}
+
+		result, err := s.upload(ctx, blogName, filename, wrap(body))
+		if err != nil {
+			return result, err
+		}
+		if err := s.attachCache.Put(key, result); err != nil {
+			return result, fmt.Errorf("tistoryAPI: save attachment cache: %w", err)
+		}
+		return result, nil
+	})
+	return shared.(model.AttachResult), err
+}
+
+// upload multipart 요청으로 r 을 올린다.
+func (s service) upload(ctx context.Context, blogName, filename string, r io.Reader) (model.AttachResult, error) {
+
+	var result model.AttachResult
+
+	query := url.Values{}
+	query.Set("access_token", s.token.AccessToken)
+	query.Set("output", "json")
+	query.Set("blogName", blogName)
+	sendUrl := s.endpoint("/post/attach", query)
+
+	// multipart form 을 메모리에 모으지 않고 파이프로 흘려보낸다.
+	// 쓰기 goroutine 의 에러는 CloseWithError 로 요청 바디 읽기 에러가 되고, errc 로도 돌려받는다.
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	errc := make(chan error, 1)
+	go func() {
+		err := writeAttachment(writer, filename, r)
+		pw.CloseWithError(err)
+		errc <- err
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sendUrl, pr)
+	if err != nil {
+		pr.CloseWithError(err)
+		<-errc
+		return result, err
 	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := s.do(req)
 
-	resp, err := s.client.Post(sendUrl, writer.FormDataContentType(), body)
+	// 서버가 바디를 다 읽기 전에 응답했거나 요청이 실패한 경우 쓰기 goroutine 을 끝낸다.
+	pr.Close()
+	if writeErr := <-errc; writeErr != nil && !errors.Is(writeErr, io.ErrClosedPipe) {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return result, fmt.Errorf("tistoryAPI: attach %s: %w", filename, writeErr)
+	}
 	if err != nil {
-		return result.Tistory, err
+		return result, err
 	}
 
-	if err := compute[model.AttachResult, model.EmptyType, model.EmptyType](&result.Tistory, resp); err != nil {
-		return result.Tistory, err
+	if err := compute[model.AttachResult, model.EmptyType, model.EmptyType](&result, resp); err != nil {
+		return result, err
 	}
-	return result.Tistory, nil
+	return result, nil
 }
 
-func (s service) GetBlogInfo() (model.BlogResult, error) {
-
-	sendUrl := TISTORY_API_URL + "/blog/info" +
-		"?access_token=" + s.accessToken +
-		"&output=json"
-
-	result := model.TistoryResult[model.BlogResult, model.EmptyType, model.EmptyType]{Tistory: model.BlogResult{}}
-
-	resp, err := s.client.Get(sendUrl)
+// writeAttachment uploadedfile 필드에 r 을 담은 multipart form 작성
+func writeAttachment(writer *multipart.Writer, filename string, r io.Reader) error {
+	part, err := writer.CreateFormFile("uploadedfile", filename)
 	if err != nil {
-		return result.Tistory, err
+		return err
 	}
-
-	if err := compute[model.BlogResult, model.EmptyType, model.EmptyType](&result.Tistory, resp); err != nil {
-		return result.Tistory, err
+	if _, err := io.Copy(part, r); err != nil {
+		return err
 	}
-	return result.Tistory, nil
+	return writer.Close()
+}
 
+func (s service) GetBlogInfo() (model.BlogResult, error) {
+	return s.GetBlogInfoContext(s.ctx)
+}
+
+func (s service) GetBlogInfoContext(ctx context.Context) (model.BlogResult, error) {
+	return call[model.BlogResult, model.EmptyType, model.EmptyType](ctx, s, http.MethodGet, "/blog/info", params{})
 }
 
 func (s service) GetPostList(blogName string, pageNumber int) (model.PostResult[model.PostListItem], error) {
-	sendUrl := TISTORY_API_URL + "/post/list" +
-		"?access_token=" + s.accessToken +
-		"&output=json" +
-		"&blogName=" + blogName +
-		"&page=" + strconv.Itoa(pageNumber)
-	result := model.TistoryResult[model.PostResult[model.PostListItem], model.PostListItem, model.EmptyType]{Tistory: model.PostResult[model.PostListItem]{}}
-	resp, err := s.client.Get(sendUrl)
-	if err != nil {
-		return result.Tistory, err
-	}
+	return s.GetPostListContext(s.ctx, blogName, pageNumber)
+}
 
-	if err := compute[model.PostResult[model.PostListItem], model.PostListItem, model.EmptyType](&result.Tistory, resp); err != nil {
-		return result.Tistory, err
-	}
-	return result.Tistory, nil
+func (s service) GetPostListContext(ctx context.Context, blogName string, pageNumber int) (model.PostResult[model.PostListItem], error) {
+	p := newParams(blogName).
+		set("page", strconv.Itoa(pageNumber))
+	return call[model.PostResult[model.PostListItem], model.PostListItem, model.EmptyType](ctx, s, http.MethodGet, "/post/list", p)
 }
 
 func (s service) GetPost(blogName, postId string) (model.PostResult[model.PostDetailItem], error) {
-	sendUrl := TISTORY_API_URL + "/post/read" +
-		"?access_token=" + s.accessToken +
-		"&output=json" +
-		"&blogName=" + blogName +
-		"&postId=" + postId
-	result := model.TistoryResult[model.PostResult[model.PostDetailItem], model.PostDetailItem, model.EmptyType]{Tistory: model.PostResult[model.PostDetailItem]{}}
-	resp, err := s.client.Get(sendUrl)
-	if err != nil {
-		return result.Tistory, err
-	}
+	return s.GetPostContext(s.ctx, blogName, postId)
+}
 
-	if err := compute[model.PostResult[model.PostDetailItem], model.PostDetailItem, model.EmptyType](&result.Tistory, resp); err != nil {
-		return result.Tistory, err
-	}
-	return result.Tistory, nil
+func (s service) GetPostContext(ctx context.Context, blogName, postId string) (model.PostResult[model.PostDetailItem], error) {
+	p := newParams(blogName).
+		set("postId", postId)
+	return call[model.PostResult[model.PostDetailItem], model.PostDetailItem, model.EmptyType](ctx, s, http.MethodGet, "/post/read", p)
 }
 
 func (s service) GetNewestCommentList(blogName string, pageNumber int, count int) (model.CommentResult[model.CommentNewestListItem], error) {
-	sendUrl := TISTORY_API_URL + "/comment/newest" +
-		"?access_token=" + s.accessToken +
-		"&output=json" +
-		"&blogName=" + blogName +
-		"&page=" + fmt.Sprint(pageNumber) +
-		"&count=" + fmt.Sprint(count)
-	result := model.TistoryResult[model.CommentResult[model.CommentNewestListItem], model.EmptyType, model.CommentNewestListItem]{Tistory: model.CommentResult[model.CommentNewestListItem]{}}
-	resp, err := s.client.Get(sendUrl)
-	if err != nil {
-		return result.Tistory, err
-	}
+	return s.GetNewestCommentListContext(s.ctx, blogName, pageNumber, count)
+}
 
-	if err := compute[model.CommentResult[model.CommentNewestListItem], model.EmptyType, model.CommentNewestListItem](&result.Tistory, resp); err != nil {
-		return result.Tistory, err
-	}
-	return result.Tistory, nil
+func (s service) GetNewestCommentListContext(ctx context.Context, blogName string, pageNumber int, count int) (model.CommentResult[model.CommentNewestListItem], error) {
+	p := newParams(blogName).
+		set("page", strconv.Itoa(pageNumber)).
+		set("count", strconv.Itoa(count))
+	return call[model.CommentResult[model.CommentNewestListItem], model.EmptyType, model.CommentNewestListItem](ctx, s, http.MethodGet, "/comment/newest", p)
 }
 
 func (s service) GetCommentList(blogName, postId string) (model.CommentResult[model.CommentListItem], error) {
-	sendUrl := TISTORY_API_URL + "/comment/list" +
-		"?access_token=" + s.accessToken +
-		"&output=json" +
-		"&blogName=" + blogName +
-		"&postId=" + postId
-	result := model.TistoryResult[model.CommentResult[model.CommentListItem], model.EmptyType, model.CommentListItem]{Tistory: model.CommentResult[model.CommentListItem]{}}
-	resp, err := s.client.Get(sendUrl)
-	if err != nil {
-		return result.Tistory, err
-	}
+	return s.GetCommentListContext(s.ctx, blogName, postId)
+}
 
-	if err := compute[model.CommentResult[model.CommentListItem], model.EmptyType, model.CommentListItem](&result.Tistory, resp); err != nil {
-		return result.Tistory, err
-	}
-	return result.Tistory, nil
+func (s service) GetCommentListContext(ctx context.Context, blogName, postId string) (model.CommentResult[model.CommentListItem], error) {
+	p := newParams(blogName).
+		set("postId", postId)
+	return call[model.CommentResult[model.CommentListItem], model.EmptyType, model.CommentListItem](ctx, s, http.MethodGet, "/comment/list", p)
 }
 
 func (s service) GetCategoryList(blogName string) (model.CategoryResult, error) {
-	sendUrl := TISTORY_API_URL + "/category/list" +
-		"?access_token=" + s.accessToken +
-		"&output=json" +
-		"&blogName=" + blogName
-	result := model.TistoryResult[model.CategoryResult, model.EmptyType, model.EmptyType]{Tistory: model.CategoryResult{}}
-	resp, err := s.client.Get(sendUrl)
-	if err != nil {
-		return result.Tistory, err
-	}
+	return s.GetCategoryListContext(s.ctx, blogName)
+}
 
-	if err := compute[model.CategoryResult, model.EmptyType, model.EmptyType](&result.Tistory, resp); err != nil {
-		return result.Tistory, err
-	}
-	return result.Tistory, nil
+func (s service) GetCategoryListContext(ctx context.Context, blogName string) (model.CategoryResult, error) {
+	p := newParams(blogName)
+	return call[model.CategoryResult, model.EmptyType, model.EmptyType](ctx, s, http.MethodGet, "/category/list", p)
+}
+
+func (s service) ResolveCategory(ctx context.Context, blogName, path string) (string, error) {
+	result, err := s.GetCategoryListContext(ctx, blogName)
+	if err != nil {
+		return "", err
+	}
+	tree := model.NewCategoryTree(result)
+	if node, ok := tree.FindByPath(path); ok {
+		return node.Id, nil
+	}
+	if !strings.Contains(path, "/") {
+		switch nodes := tree.FindByName(path); len(nodes) {
+		case 0:
+		case 1:
+			return nodes[0].Id, nil
+		default:
+			paths := make([]string, len(nodes))
+			for i, node := range nodes {
+				paths[i] = node.Path
+			}
+			return "", fmt.Errorf("%w: %q (%s)", ErrCategoryAmbiguous, path, strings.Join(paths, ", "))
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrCategoryNotFound, path)
 }
 
 func (s service) UpdatePost(data model.PostUpdateData) (model.PostWriteResult, error) {
-	// SET Result Record and Input Record
-	result := model.TistoryResult[model.PostWriteResult, model.EmptyType, model.EmptyType]{Tistory: model.PostWriteResult{}}
-	sendUrl := TISTORY_API_URL + "/post/modify" +
-		"?access_token=" + s.accessToken +
-		"&output=json" +
-		"&blogName=" + data.BlogName +
-		"&postId=" + data.PostId +
-		"&title=" + data.Title +
-		"&content=" + data.Content +
-		"&visibility=" + data.Visibility +
-		"&category=" + data.Category +
-		"&published=" + data.Published +
-		"&slogan=" + data.Slogan +
-		"&tag=" + data.Tag +
-		"&acceptComment=" + data.AcceptComment +
-		"&password=" + data.Password
-	resp, err := s.client.Post(sendUrl, "application/json", http.NoBody)
+	return s.UpdatePostContext(s.ctx, data)
+}
+
+func (s service) UpdatePostContext(ctx context.Context, data model.PostUpdateData) (model.PostWriteResult, error) {
+	if err := data.Validate(); err != nil {
+		return model.PostWriteResult{}, err
+	}
+	p := newParams(data.BlogName).
+		set("postId", data.PostId).
+		set("title", data.Title).
+		set("content", data.Content).
+		set("visibility", data.Visibility.String()).
+		set("category", data.Category).
+		set("published", data.Published).
+		set("slogan", data.Slogan).
+		set("tag", data.Tag).
+		set("acceptComment", data.AcceptComment.String()).
+		set("password", data.Password)
+	return call[model.PostWriteResult, model.EmptyType, model.EmptyType](ctx, s, http.MethodPost, "/post/modify", p)
+}
+
+func (s service) PatchPost(ctx context.Context, blogName, postId string, patch model.PostPatch) (model.PostDetailItem, model.PostDetailItem, error) {
+	current, err := s.GetPostContext(ctx, blogName, postId)
 	if err != nil {
-		return result.Tistory, err
+		return model.PostDetailItem{}, model.PostDetailItem{}, err
+	}
+	before := current.Item
+
+	data := model.UpdateFrom(before)
+	data.BlogName = blogName
+	data.PostId = postId
+	patch.Apply(&data)
+
+	// 상세 API 는 비밀번호를 내려주지 않으므로, 보호글을 그대로 보내면 비밀번호가 지워진다.
+	if before.Visibility == model.Protected && data.Visibility == model.Protected && data.Password == "" {
+		return model.PostDetailItem{}, model.PostDetailItem{}, &model.FieldError{Field: "password", Message: "보호글을 수정할 때는 patch 에 비밀번호를 다시 넣어야 합니다."}
 	}
 
-	if err := compute[model.PostWriteResult, model.EmptyType, model.EmptyType](&result.Tistory, resp); err != nil {
-		return result.Tistory, err
+	if _, err := s.UpdatePostContext(ctx, data); err != nil {
+		return before, model.PostDetailItem{}, err
 	}
-	return result.Tistory, nil
-}
 
-func (s service) UpdateComment(data model.CommentUpdateData) (model.CommentWriteResult, error) {
-	// SET Result Record and Input Record
-	result := model.TistoryResult[model.CommentWriteResult, model.EmptyType, model.EmptyType]{Tistory: model.CommentWriteResult{}}
-	sendUrl := TISTORY_API_URL + "/comment/modify" +
-		"?access_token=" + s.accessToken +
-		"&output=json" +
-		"&blogName=" + data.BlogName +
-		"&postId=" + data.PostId +
-		"&parentId=" + data.ParentId +
-		"&commentId=" + data.CommentId +
-		"&content=" + data.Content +
-		"&secret=" + data.Secret
-	resp, err := s.client.Post(sendUrl, "application/json", http.NoBody)
+	updated, err := s.GetPostContext(ctx, blogName, postId)
 	if err != nil {
-		return result.Tistory, err
+		return before, model.PostDetailItem{}, err
 	}
+	return before, updated.Item, nil
+}
 
-	if err := compute[model.CommentWriteResult, model.EmptyType, model.EmptyType](&result.Tistory, resp); err != nil {
-		return result.Tistory, err
-	}
+func (s service) UpdateComment(data model.CommentUpdateData) (model.CommentWriteResult, error) {
+	return s.UpdateCommentContext(s.ctx, data)
+}
 
-	return result.Tistory, nil
+func (s service) UpdateCommentContext(ctx context.Context, data model.CommentUpdateData) (model.CommentWriteResult, error) {
+	if err := data.Validate(); err != nil {
+		return model.CommentWriteResult{}, err
+	}
+	p := newParams(data.BlogName).
+		set("postId", data.PostId).
+		set("parentId", data.ParentId).
+		set("commentId", data.CommentId).
+		set("content", data.Content).
+		set("secret", data.Secret.String())
+	return call[model.CommentWriteResult, model.EmptyType, model.EmptyType](ctx, s, http.MethodPost, "/comment/modify", p)
 }
 
 func (s service) DeleteComment(blogName string, postId string, commentId string) (model.CommentDeleteResult, error) {
-	// SET Result Record and Input Record
-	result := model.TistoryResult[model.CommentDeleteResult, model.EmptyType, model.EmptyType]{Tistory: model.CommentDeleteResult{}}
-	sendUrl := TISTORY_API_URL + "/comment/delete" +
-		"?access_token=" + s.accessToken +
-		"&output=json" +
-		"&blogName=" + blogName +
-		"&postId=" + postId +
-		"&commentId=" + commentId
-
-	resp, err := s.client.Post(sendUrl, "application/json", http.NoBody)
-	if err != nil {
-		return result.Tistory, err
-	}
+	return s.DeleteCommentContext(s.ctx, blogName, postId, commentId)
+}
 
-	if err := compute[model.CommentDeleteResult, model.EmptyType, model.EmptyType](&result.Tistory, resp); err != nil {
-		return result.Tistory, err
-	}
-	return result.Tistory, nil
+func (s service) DeleteCommentContext(ctx context.Context, blogName string, postId string, commentId string) (model.CommentDeleteResult, error) {
+	p := newParams(blogName).
+		set("postId", postId).
+		set("commentId", commentId)
+	return call[model.CommentDeleteResult, model.EmptyType, model.EmptyType](ctx, s, http.MethodPost, "/comment/delete", p)
 }
 
 func compute[T model.Tistory[PI, CI], PI model.PostItemModel, CI model.CommentItemModel](result *T, resp *http.Response) error {
@@ -408,12 +584,54 @@ func compute[T model.Tistory[PI, CI], PI model.PostItemModel, CI model.CommentIt
 		return err
 	}
 
-	if err := json.Unmarshal(all, result); err != nil {
+	if err := checkResponse(resp, all); err != nil {
 		return err
 	}
+
+	// 응답은 {"tistory": {...}} 형태이므로 래퍼를 통해 꺼낸다.
+	wrapper := model.TistoryResult[T, PI, CI]{}
+	if err := json.Unmarshal(all, &wrapper); err != nil {
+		return err
+	}
+	*result = wrapper.Tistory
 	return nil
 }
 
+// checkResponse 실패 응답이면 *APIError 를 반환한다.
+// HTTP 상태코드가 2xx 가 아니거나, Tistory status 값이 200 이 아니면 실패로 본다.
+func checkResponse(resp *http.Response, body []byte) error {
+
+	envelope := struct {
+		Tistory struct {
+			Status       string `json:"status"`
+			ErrorMessage string `json:"error_message"`
+		} `json:"tistory"`
+	}{}
+	// 에러 페이지(HTML 등)는 파싱에 실패하므로 무시하고 HTTP 상태코드로만 판단한다.
+	_ = json.Unmarshal(body, &envelope)
+
+	status := envelope.Tistory.Status
+	httpOk := resp.StatusCode >= 200 && resp.StatusCode < 300
+	if httpOk && (status == "" || status == "200") && envelope.Tistory.ErrorMessage == "" {
+		return nil
+	}
+
+	apiErr := &APIError{
+		HTTPStatus: resp.StatusCode,
+		Status:     status,
+		Message:    envelope.Tistory.ErrorMessage,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+	}
+	if resp.Request != nil && resp.Request.URL != nil {
+		apiErr.Endpoint = resp.Request.URL.Path
+	}
+	return apiErr
+}
+
 func (s service) GetACToken() string {
-	return s.accessToken
+	return s.token.AccessToken
+}
+
+func (s service) Token() model.Token {
+	return s.token
 }
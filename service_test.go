@@ -1,80 +1,62 @@
 package tistoryAPI
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"github.com/fineroot1253/tistoryAPI/model"
+	"github.com/fineroot1253/tistoryAPI/tistorytest"
 	"github.com/stretchr/testify/assert"
-	"io/ioutil"
+	"io"
 	"log"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
 /**
- 	테스트 이후 직접 포스트 삭제할 것
-	1. 서비스 생성
-	2. 블로그 정보 요청
-	3. 카테고리 정보 요청, 이후 결과 전역변수로 등록
-	4. 블로그 글 쓰기 요청, 이후 결과 포스트 ID 전역 변수로 등록
-	5. 블로그 댓글 쓰기 요청, 이후 결과 댓글 ID 전역 변수로 등록
-	6. 블로그 글 목록 요청
-	7. 블로그 최신 댓글 목록 요청
-	8. 블로그 글 수정 요청
-	9. 블로그 댓글 수정 요청
-	10. 블로그 댓글 삭제 요청
-	11. 블로그 댓글 목록 요청
-	12. 블로그 글 첨부 파일 추가 요청
-	13. 블로그 글 읽기 요청
+tistorytest 가짜 서버를 대상으로 모든 Service 메서드를 테스트한다.
+테스트마다 독립된 서버를 띄우므로 실계정, 실행 순서와 상관 없이 돌릴 수 있다.
+1. 서비스 생성
+2. 블로그 정보 요청
+3. 카테고리 정보 요청
+4. 블로그 글 쓰기 요청
+5. 블로그 댓글 쓰기 요청
+6. 블로그 글 목록 요청
+7. 블로그 최신 댓글 목록 요청
+8. 블로그 글 수정 요청
+9. 블로그 댓글 수정 요청
+10. 블로그 댓글 삭제 요청
+11. 블로그 댓글 목록 요청
+12. 블로그 글 첨부 파일 추가 요청
+13. 블로그 글 읽기 요청
 */
 
-var userData model.UserData
-var commentData model.CommentData
-var commentUpdateData model.CommentUpdateData
-var postData model.PostData
-var postUpdateData model.PostUpdateData
-var blogInfo struct {
-	blogName string `json:"blog_name"`
+var postData = model.PostData{
+	BlogName:   tistorytest.BlogName,
+	Title:      "테스트 글",
+	Content:    "<p>테스트 입니다.</p>",
+	Visibility: model.Public,
+	Tag:        "go,tistory",
 }
-var commonService Service
 
-func init() {
-	//todo 더미 데이터 초기화
-	commonInitData, err := ioutil.ReadFile("testdata/test_common_data.json")
-	if err != nil {
-		log.Fatal(err)
-	}
-	if err := json.Unmarshal(commonInitData, &userData); err != nil {
-		log.Fatal(err)
-	}
-	if err := json.Unmarshal(commonInitData, &blogInfo); err != nil {
-		log.Fatal(err)
-	}
-
-	commentDummy, err := ioutil.ReadFile("testdata/test_comment_data.json")
-	if err != nil {
-		log.Fatal(err)
-	}
-	if err := json.Unmarshal(commentDummy, &commentData); err != nil {
-		log.Fatal(err)
-	}
-	if err := json.Unmarshal(commentDummy, &commentUpdateData); err != nil {
-		log.Fatal(err)
-	}
+// newTestService 가짜 서버와 그 서버를 바라보는 서비스 생성
+func newTestService(t *testing.T, opts ...Option) (Service, *tistorytest.Server) {
+	srv := tistorytest.NewServer()
+	t.Cleanup(srv.Close)
 
-	postDummy, err := ioutil.ReadFile("testdata/test_post_data.json")
+	opts = append([]Option{WithBaseURL(srv.APIURL()), WithOAuthURL(srv.OAuthURL())}, opts...)
+	serv, err := NewService(context.Background(), srv.UserData(), opts...)
 	if err != nil {
-		log.Fatal(err)
-	}
-	if err := json.Unmarshal(postDummy, &postData); err != nil {
-		log.Fatal(err)
+		t.Fatal(err)
 	}
-	if err := json.Unmarshal(postDummy, &postUpdateData); err != nil {
-		log.Fatal(err)
-	}
-
+	return serv, srv
 }
 
 func TestService(t *testing.T) {
+	srv := tistorytest.NewServer()
+	defer srv.Close()
 
 	type args struct {
 		ctx      context.Context
@@ -90,7 +72,7 @@ func TestService(t *testing.T) {
 			name: "서비스 생성 테스트:[success]",
 			args: args{
 				ctx:      context.Background(),
-				userData: userData,
+				userData: srv.UserData(),
 			},
 			wantErr: false,
 		},
@@ -104,19 +86,17 @@ func TestService(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
-		serv, err := NewService(tt.args.ctx, tt.args.userData)
-		if err != nil {
+		t.Run(tt.name, func(t *testing.T) {
+			serv, err := NewService(tt.args.ctx, tt.args.userData, WithBaseURL(srv.APIURL()), WithOAuthURL(srv.OAuthURL()))
 			if tt.wantErr {
-				log.Println(err)
-			} else {
-				log.Panicln(err)
+				assert.Error(t, err)
+				return
 			}
-		} else {
+			assert.NoError(t, err)
 			token := serv.GetACToken()
-			assert.NotEmpty(t, token)
+			assert.Equal(t, tistorytest.AccessToken, token)
 			log.Println("NewService complete: ", token)
-			commonService = serv
-		}
+		})
 	}
 }
 
@@ -130,24 +110,18 @@ func Test_service_GetBlogInfo(t *testing.T) {
 			wantErr: false,
 		},
 	}
-	var serv Service
-	if err := getService(serv); err != nil {
-		log.Panicln(err)
-	}
+	serv, _ := newTestService(t)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
 			info, err := serv.GetBlogInfo()
-			if err != nil {
-				if tt.wantErr {
-					log.Println(err)
-				} else {
-					log.Panicln(err)
-				}
-			} else {
-				assert.NotEmpty(t, info)
-				log.Println("GetBlogInfo Complete: ", info)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
 			}
+			assert.NoError(t, err)
+			assert.Equal(t, tistorytest.BlogName, info.Item.Blogs[0].Name)
+			log.Println("GetBlogInfo Complete: ", info)
 		})
 	}
 }
@@ -163,7 +137,7 @@ func Test_service_GetCategoryList(t *testing.T) {
 	}{
 		{
 			name:    "테스트:[success]",
-			args:    args{blogName: blogInfo.blogName},
+			args:    args{blogName: tistorytest.BlogName},
 			wantErr: false,
 		},
 		{
@@ -172,23 +146,57 @@ func Test_service_GetCategoryList(t *testing.T) {
 			wantErr: true,
 		},
 	}
-	var serv Service
-	if err := getService(serv); err != nil {
-		log.Panicln(err)
-	}
+	serv, srv := newTestService(t)
+	parent := srv.AddCategory("Dev", "")
+	srv.AddCategory("Go", parent)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := serv.GetCategoryList(tt.args.blogName)
-			if err != nil {
-				if tt.wantErr {
-					log.Println(err)
-				} else {
-					log.Panicln(err)
-				}
-			} else {
-				assert.NotEmpty(t, got)
-				log.Println("GetCategoryList Complete: ", got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got.Item.Categories, 2)
+			assert.Equal(t, "Dev/Go", got.Item.Categories[1].Label)
+			log.Println("GetCategoryList Complete: ", got)
+		})
+	}
+}
+
+func Test_service_ResolveCategory(t *testing.T) {
+	serv, srv := newTestService(t)
+	dev := srv.AddCategory("Dev", "")
+	goId := srv.AddCategory("Go", dev)
+	life := srv.AddCategory("Life", "")
+	srv.AddCategory("Notes", dev)
+	srv.AddCategory("Notes", life)
+
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr error
+	}{
+		{name: "최상위 카테고리:[success]", path: "Dev", want: dev},
+		{name: "하위 카테고리:[success]", path: "Dev/Go", want: goId},
+		{name: "앞뒤 공백, 슬래시:[success]", path: "/Dev / Go/", want: goId},
+		{name: "없는 경로:[failure]", path: "Life/Go", wantErr: ErrCategoryNotFound},
+		{name: "빈 경로:[failure]", path: "", wantErr: ErrCategoryNotFound},
+		{name: "이름:[success]", path: " Go ", want: goId},
+		{name: "없는 이름:[failure]", path: "Rust", wantErr: ErrCategoryNotFound},
+		{name: "같은 이름 여러 개:[failure]", path: "Notes", wantErr: ErrCategoryAmbiguous},
+		{name: "경로는 이름으로 찾지 않음:[failure]", path: "/Go", wantErr: ErrCategoryNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := serv.ResolveCategory(context.Background(), tistorytest.BlogName, tt.path)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
 			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
 		})
 	}
 }
@@ -217,29 +225,30 @@ func Test_service_WritePost(t *testing.T) {
 			wantErr: true,
 		},
 	}
-	var serv Service
-	if err := getService(serv); err != nil {
-		log.Panicln(err)
-	}
+	serv, _ := newTestService(t)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := serv.WritePost(tt.args.data)
-			if err != nil {
-				if tt.wantErr {
-					log.Println(err)
-				} else {
-					log.Panicln(err)
-				}
-			} else {
-				assert.NotEmpty(t, got)
-				postUpdateData.PostId = got.PostId
-				log.Println("WritePost Complete: ", got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
 			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, got.PostId)
+
+			post, err := serv.GetPost(tistorytest.BlogName, got.PostId)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.args.data.Title, post.Item.Title)
+			assert.Equal(t, tt.args.data.Content, post.Item.Content)
+			log.Println("WritePost Complete: ", got)
 		})
 	}
 }
 
 func Test_service_WriteComment(t *testing.T) {
+	serv, srv := newTestService(t)
+	postId := srv.AddPost(postData)
+
 	type args struct {
 		data model.CommentData
 	}
@@ -250,7 +259,7 @@ func Test_service_WriteComment(t *testing.T) {
 	}{
 		{
 			name:    "댓글 쓰기 테스트:[success]",
-			args:    args{data: commentData},
+			args:    args{data: model.CommentData{BlogName: tistorytest.BlogName, PostId: postId, Content: "댓글 & 테스트"}},
 			wantErr: false,
 		},
 		{
@@ -259,23 +268,20 @@ func Test_service_WriteComment(t *testing.T) {
 			wantErr: true,
 		},
 	}
-	var serv Service
-	if err := getService(serv); err != nil {
-		log.Panicln(err)
-	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := serv.WriteComment(tt.args.data)
-			if err != nil {
-				if tt.wantErr {
-					log.Println(err)
-				} else {
-					log.Panicln(err)
-				}
-			} else {
-				assert.NotEmpty(t, got)
-				log.Println("WriteComment Complete: ", got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
 			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, got.CommentUrl)
+
+			list, err := serv.GetCommentList(tistorytest.BlogName, postId)
+			assert.NoError(t, err)
+			assert.Equal(t, "댓글 & 테스트", list.Item.Comments.Comment[0].Comment)
+			log.Println("WriteComment Complete: ", got)
 		})
 	}
 }
@@ -293,7 +299,7 @@ func Test_service_GetPostList(t *testing.T) {
 		{
 			name: "글 목록 읽기 테스트:[success]",
 			args: args{
-				blogName:   blogInfo.blogName,
+				blogName:   tistorytest.BlogName,
 				pageNumber: 1,
 			},
 			wantErr: false,
@@ -301,7 +307,7 @@ func Test_service_GetPostList(t *testing.T) {
 		{
 			name: "글 목록 읽기 테스트:[failure] (페이지 넘버 디폴트값 오류)",
 			args: args{
-				blogName:   blogInfo.blogName,
+				blogName:   tistorytest.BlogName,
 				pageNumber: 0,
 			},
 			wantErr: true,
@@ -323,23 +329,19 @@ func Test_service_GetPostList(t *testing.T) {
 			wantErr: true,
 		},
 	}
-	var serv Service
-	if err := getService(serv); err != nil {
-		log.Panicln(err)
-	}
+	serv, srv := newTestService(t)
+	srv.AddPost(postData)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := serv.GetPostList(tt.args.blogName, tt.args.pageNumber)
-			if err != nil {
-				if tt.wantErr {
-					log.Println(err)
-				} else {
-					log.Panicln(err)
-				}
-			} else {
-				assert.NotEmpty(t, got)
-				log.Println("GetPostList Complete: ", got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
 			}
+			assert.NoError(t, err)
+			assert.Len(t, got.Item.Posts, 1)
+			assert.Equal(t, model.Public, got.Item.Posts[0].Visibility)
+			log.Println("GetPostList Complete: ", got)
 		})
 	}
 }
@@ -358,7 +360,7 @@ func Test_service_GetNewestCommentList(t *testing.T) {
 		{
 			name: "최신 댓글 목록 읽기 테스트:[success]",
 			args: args{
-				blogName:   blogInfo.blogName,
+				blogName:   tistorytest.BlogName,
 				pageNumber: 1,
 				count:      5,
 			},
@@ -367,7 +369,7 @@ func Test_service_GetNewestCommentList(t *testing.T) {
 		{
 			name: "최신 댓글 목록 읽기 테스트:[failure] (페이지 넘버 디폴트값 오류)",
 			args: args{
-				blogName:   blogInfo.blogName,
+				blogName:   tistorytest.BlogName,
 				pageNumber: 0,
 				count:      5,
 			},
@@ -376,7 +378,7 @@ func Test_service_GetNewestCommentList(t *testing.T) {
 		{
 			name: "최신 댓글 목록 읽기 테스트:[failure] (카운트 넘버 디폴트 값 오류)",
 			args: args{
-				blogName:   blogInfo.blogName,
+				blogName:   tistorytest.BlogName,
 				pageNumber: 1,
 				count:      11,
 			},
@@ -401,28 +403,29 @@ func Test_service_GetNewestCommentList(t *testing.T) {
 			wantErr: true,
 		},
 	}
-	var serv Service
-	if err := getService(serv); err != nil {
-		log.Panicln(err)
+	serv, srv := newTestService(t)
+	postId := srv.AddPost(postData)
+	for i := 0; i < 7; i++ {
+		srv.AddComment(model.CommentData{PostId: postId, Content: "댓글"}, "방문자")
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := serv.GetNewestCommentList(tt.args.blogName, tt.args.pageNumber, tt.args.count)
-			if err != nil {
-				if tt.wantErr {
-					log.Println(err)
-				} else {
-					log.Panicln(err)
-				}
-			} else {
-				assert.NotEmpty(t, got)
-				log.Println("GetNewestCommentList Complete: ", got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
 			}
+			assert.NoError(t, err)
+			assert.Len(t, got.Item.Comments.Comment, tt.args.count)
+			log.Println("GetNewestCommentList Complete: ", got)
 		})
 	}
 }
 
 func Test_service_UpdatePost(t *testing.T) {
+	serv, srv := newTestService(t)
+	postId := srv.AddPost(postData)
+
 	type args struct {
 		data model.PostUpdateData
 	}
@@ -435,7 +438,7 @@ func Test_service_UpdatePost(t *testing.T) {
 		{
 			name: "글 수정 테스트:[success]",
 			args: args{
-				data: postUpdateData,
+				data: model.PostUpdateData{PostId: postId, PostData: model.PostData{BlogName: tistorytest.BlogName, Title: "수정된 제목", Content: "<p>수정</p>"}},
 			},
 			wantErr: false,
 		},
@@ -447,28 +450,122 @@ func Test_service_UpdatePost(t *testing.T) {
 			wantErr: true,
 		},
 	}
-	var serv Service
-	if err := getService(serv); err != nil {
-		log.Panicln(err)
-	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := serv.UpdatePost(tt.args.data)
-			if err != nil {
-				if tt.wantErr {
-					log.Println(err)
-				} else {
-					log.Panicln(err)
-				}
-			} else {
-				assert.NotEmpty(t, got)
-				log.Println("UpdatePost Complete: ", got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, postId, got.PostId)
+
+			post, err := serv.GetPost(tistorytest.BlogName, postId)
+			assert.NoError(t, err)
+			assert.Equal(t, "수정된 제목", post.Item.Title)
+			log.Println("UpdatePost Complete: ", got)
+		})
+	}
+}
+
+func Test_service_PatchPost(t *testing.T) {
+	serv, srv := newTestService(t)
+	categoryId := srv.AddCategory("Dev", "")
+
+	title := "수정된 제목"
+	tags := []string{}
+	password := "새 비밀번호"
+	public := model.Public
+	tests := []struct {
+		name     string
+		postId   string
+		password string
+		patch    model.PostPatch
+		check    func(t *testing.T, before, after model.PostDetailItem)
+		wantErr  error
+	}{
+		{
+			name:  "제목만 수정:[success]",
+			patch: model.PostPatch{Title: &title},
+			check: func(t *testing.T, before, after model.PostDetailItem) {
+				assert.Equal(t, postData.Title, before.Title)
+				assert.Equal(t, title, after.Title)
+				assert.Equal(t, before.Content, after.Content)
+				assert.Equal(t, before.CategoryId, after.CategoryId)
+				assert.Equal(t, before.Visibility, after.Visibility)
+				assert.Equal(t, []string{"go", "tistory"}, after.Tags.Tag)
+				assert.True(t, before.Date.Equal(after.Date.Time))
+			},
+		},
+		{
+			name:  "태그 비우기:[success]",
+			patch: model.PostPatch{Tags: &tags},
+			check: func(t *testing.T, before, after model.PostDetailItem) {
+				assert.Equal(t, []string{"go", "tistory"}, before.Tags.Tag)
+				assert.Empty(t, after.Tags.Tag)
+				assert.Equal(t, before.Title, after.Title)
+			},
+		},
+		{
+			name:    "없는 글 수정:[failure]",
+			postId:  "404",
+			patch:   model.PostPatch{Title: &title},
+			wantErr: ErrNotFound,
+		},
+		{
+			name:     "보호글 비밀번호 없이 수정:[failure]",
+			password: "비밀번호",
+			patch:    model.PostPatch{Title: &title},
+			wantErr:  model.ErrInvalidData,
+		},
+		{
+			name:     "보호글 비밀번호와 함께 수정:[success]",
+			password: "비밀번호",
+			patch:    model.PostPatch{Title: &title, Password: &password},
+			check: func(t *testing.T, before, after model.PostDetailItem) {
+				assert.Equal(t, model.Protected, after.Visibility)
+				assert.Equal(t, title, after.Title)
+			},
+		},
+		{
+			name:     "보호글 공개로 전환:[success]",
+			password: "비밀번호",
+			patch:    model.PostPatch{Visibility: &public},
+			check: func(t *testing.T, before, after model.PostDetailItem) {
+				assert.Equal(t, model.Protected, before.Visibility)
+				assert.Equal(t, model.Public, after.Visibility)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := postData
+			data.Category = categoryId
+			if tt.password != "" {
+				data.Visibility = model.Protected
+				data.Password = tt.password
 			}
+			postId := srv.AddPost(data)
+			if tt.postId != "" {
+				postId = tt.postId
+			}
+
+			before, after, err := serv.PatchPost(context.Background(), tistorytest.BlogName, postId, tt.patch)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			tt.check(t, before, after)
 		})
 	}
 }
 
 func Test_service_UpdateComment(t *testing.T) {
+	serv, srv := newTestService(t)
+	postId := srv.AddPost(postData)
+	commentId := srv.AddComment(model.CommentData{PostId: postId, Content: "댓글"}, "방문자")
+
 	type args struct {
 		data model.CommentUpdateData
 	}
@@ -481,7 +578,7 @@ func Test_service_UpdateComment(t *testing.T) {
 		{
 			name: "댓글 수정 테스트:[success]",
 			args: args{
-				data: commentUpdateData,
+				data: model.CommentUpdateData{CommentId: commentId, CommentData: model.CommentData{BlogName: tistorytest.BlogName, PostId: postId, Content: "수정된 댓글", Secret: model.CommentSecret}},
 			},
 			wantErr: false,
 		},
@@ -491,28 +588,29 @@ func Test_service_UpdateComment(t *testing.T) {
 			wantErr: true,
 		},
 	}
-	var serv Service
-	if err := getService(serv); err != nil {
-		log.Panicln(err)
-	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := serv.UpdateComment(tt.args.data)
-			if err != nil {
-				if tt.wantErr {
-					log.Println(err)
-				} else {
-					log.Panicln(err)
-				}
-			} else {
-				assert.NotEmpty(t, got)
-				log.Println("GetCategoryList Complete: ", got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
 			}
+			assert.NoError(t, err)
+
+			list, err := serv.GetCommentList(tistorytest.BlogName, postId)
+			assert.NoError(t, err)
+			assert.Equal(t, "수정된 댓글", list.Item.Comments.Comment[0].Comment)
+			assert.Equal(t, model.YN(false), list.Item.Comments.Comment[0].Open)
+			log.Println("UpdateComment Complete: ", got)
 		})
 	}
 }
 
 func Test_service_DeleteComment(t *testing.T) {
+	serv, srv := newTestService(t)
+	postId := srv.AddPost(postData)
+	commentId := srv.AddComment(model.CommentData{PostId: postId, Content: "댓글"}, "방문자")
+
 	type args struct {
 		blogName  string
 		postId    string
@@ -527,9 +625,9 @@ func Test_service_DeleteComment(t *testing.T) {
 		{
 			name: "댓글 삭제 테스트:[success]",
 			args: args{
-				blogName:  blogInfo.blogName,
-				postId:    commentUpdateData.PostId,
-				commentId: commentUpdateData.CommentId,
+				blogName:  tistorytest.BlogName,
+				postId:    postId,
+				commentId: commentId,
 			},
 			wantErr: false,
 		},
@@ -543,28 +641,29 @@ func Test_service_DeleteComment(t *testing.T) {
 			wantErr: true,
 		},
 	}
-	var serv Service
-	if err := getService(serv); err != nil {
-		log.Panicln(err)
-	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := serv.DeleteComment(tt.args.blogName, tt.args.postId, tt.args.commentId)
-			if err != nil {
-				if tt.wantErr {
-					log.Println(err)
-				} else {
-					log.Panicln(err)
-				}
-			} else {
-				assert.NotEmpty(t, got)
-				log.Println("GetCategoryList Complete: ", got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
 			}
+			assert.NoError(t, err)
+
+			list, err := serv.GetCommentList(tistorytest.BlogName, postId)
+			assert.NoError(t, err)
+			assert.Empty(t, list.Item.Comments.Comment)
+			log.Println("DeleteComment Complete: ", got)
 		})
 	}
 }
 
 func Test_service_GetCommentList(t *testing.T) {
+	serv, srv := newTestService(t)
+	postId := srv.AddPost(postData)
+	parentId := srv.AddComment(model.CommentData{PostId: postId, Content: "댓글"}, "방문자")
+	srv.AddComment(model.CommentData{PostId: postId, ParentId: parentId, Content: "대댓글"}, "주인")
+
 	type args struct {
 		blogName string
 		postId   string
@@ -577,8 +676,8 @@ func Test_service_GetCommentList(t *testing.T) {
 		{
 			name: "글 댓글 목록 읽기 테스트:[success]",
 			args: args{
-				blogName: blogInfo.blogName,
-				postId:   commentUpdateData.PostId,
+				blogName: tistorytest.BlogName,
+				postId:   postId,
 			},
 			wantErr: false,
 		},
@@ -591,28 +690,27 @@ func Test_service_GetCommentList(t *testing.T) {
 			wantErr: true,
 		},
 	}
-	var serv Service
-	if err := getService(serv); err != nil {
-		log.Panicln(err)
-	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := serv.GetCommentList(tt.args.blogName, tt.args.postId)
-			if err != nil {
-				if tt.wantErr {
-					log.Println(err)
-				} else {
-					log.Panicln(err)
-				}
-			} else {
-				assert.NotEmpty(t, got)
-				log.Println("GetCategoryList Complete: ", got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
 			}
+			assert.NoError(t, err)
+			assert.Equal(t, model.FlexInt(2), got.Item.TotalCount)
+			assert.Equal(t, parentId, got.Item.Comments.Comment[1].ParentId)
+			log.Println("GetCommentList Complete: ", got)
 		})
 	}
 }
 
 func Test_service_AttachFiles(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "square-gopher.png")
+	if err := os.WriteFile(filePath, []byte("\x89PNG gopher"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
 	type args struct {
 		blogName string
 		filePath string
@@ -626,42 +724,110 @@ func Test_service_AttachFiles(t *testing.T) {
 		{
 			name: "테스트:[success]",
 			args: args{
-				blogName: blogInfo.blogName,
-				filePath: "testdata/square-gopher.png",
+				blogName: tistorytest.BlogName,
+				filePath: filePath,
 			},
 			wantErr: false,
 		},
 		{
-			name: "테스트:[failure] (필수 필드 포함 전부 생략)",
+			name: "테스트:[failure] (필수값인 블로그 이름 생략)",
 			args: args{
 				blogName: "",
-				filePath: "",
+				filePath: filePath,
+			},
+			wantErr: true,
+		},
+		{
+			name: "테스트:[failure] (없는 파일)",
+			args: args{
+				blogName: tistorytest.BlogName,
+				filePath: filepath.Join(t.TempDir(), "missing.png"),
 			},
 			wantErr: true,
 		},
 	}
-	var serv Service
-	if err := getService(serv); err != nil {
-		log.Panicln(err)
-	}
+	serv, srv := newTestService(t)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := serv.AttachFiles(tt.args.blogName, tt.args.filePath)
-			if err != nil {
-				if tt.wantErr {
-					log.Println(err)
-				} else {
-					log.Panicln(err)
-				}
-			} else {
-				assert.NotEmpty(t, got)
-				log.Println("GetCategoryList Complete: ", got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, got.Url)
+			assert.NotEmpty(t, got.Replacer)
+			assert.Len(t, srv.Attachments(), 1)
+			assert.Equal(t, "square-gopher.png", srv.Attachments()[0].Name)
+			log.Println("AttachFiles Complete: ", got)
+		})
+	}
+}
+
+// failingReader n 바이트를 읽은 뒤 err 를 반환한다.
+type failingReader struct {
+	n   int
+	err error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.n <= 0 {
+		return 0, r.err
+	}
+	n := min(len(p), r.n)
+	r.n -= n
+	return n, nil
+}
+
+func Test_service_AttachReader(t *testing.T) {
+	readErr := errors.New("generate failed")
+	tests := []struct {
+		name     string
+		blogName string
+		reader   io.Reader
+		wantSize int64
+		wantErr  error
+	}{
+		{
+			name:     "메모리 이미지 업로드:[success]",
+			blogName: tistorytest.BlogName,
+			reader:   bytes.NewReader(bytes.Repeat([]byte("gopher"), 1<<16)),
+			wantSize: 6 << 16,
+		},
+		{
+			name:     "읽기 실패:[failure]",
+			blogName: tistorytest.BlogName,
+			reader:   &failingReader{n: 1 << 16, err: readErr},
+			wantErr:  readErr,
+		},
+		{
+			name:     "API 실패:[failure]",
+			blogName: "unknown-blog",
+			reader:   strings.NewReader("gopher"),
+			wantErr:  ErrBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serv, srv := newTestService(t)
+
+			got, err := serv.AttachReader(context.Background(), tt.blogName, "generated.png", tt.reader)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, srv.Attachments())
+				return
 			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, got.Replacer)
+			assert.Equal(t, []tistorytest.Attachment{{Name: "generated.png", Size: tt.wantSize, Result: got}}, srv.Attachments())
 		})
 	}
 }
 
 func Test_service_GetPost(t *testing.T) {
+	serv, srv := newTestService(t)
+	postId := srv.AddPost(postData)
+
 	type args struct {
 		blogName string
 		postId   string
@@ -674,8 +840,8 @@ func Test_service_GetPost(t *testing.T) {
 		{
 			name: "테스트:[success]",
 			args: args{
-				blogName: blogInfo.blogName,
-				postId:   commentUpdateData.PostId,
+				blogName: tistorytest.BlogName,
+				postId:   postId,
 			},
 			wantErr: false,
 		},
@@ -687,37 +853,26 @@ func Test_service_GetPost(t *testing.T) {
 			},
 			wantErr: true,
 		},
-	}
-	var serv Service
-	if err := getService(serv); err != nil {
-		log.Panicln(err)
+		{
+			name: "테스트:[failure] (없는 글)",
+			args: args{
+				blogName: tistorytest.BlogName,
+				postId:   "999",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := serv.GetPost(tt.args.blogName, tt.args.postId)
-			if err != nil {
-				if tt.wantErr {
-					log.Println(err)
-				} else {
-					log.Panicln(err)
-				}
-			} else {
-				assert.NotEmpty(t, got)
-				log.Println("GetCategoryList Complete: ", got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
 			}
+			assert.NoError(t, err)
+			assert.Equal(t, postData.Title, got.Item.Title)
+			assert.Equal(t, []string{"go", "tistory"}, got.Item.Tags.Tag)
+			log.Println("GetPost Complete: ", got)
 		})
 	}
 }
-
-func getService(serv Service) error {
-	if commonService != nil {
-		serv = commonService
-	} else {
-		var err error
-		serv, err = NewService(context.Background(), userData)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
@@ -0,0 +1,675 @@
+// Package tistorytest 오프라인 테스트용 가짜 Tistory 서버
+// httptest.Server 위에 OAuth 토큰 발급과 Tistory Open API 를 메모리 상태로 구현한다.
+//
+//	srv := tistorytest.NewServer()
+//	defer srv.Close()
+//	service, err := tistoryAPI.NewService(ctx, srv.UserData(),
+//		tistoryAPI.WithBaseURL(srv.APIURL()), tistoryAPI.WithOAuthURL(srv.OAuthURL()))
+package tistorytest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// BlogName 가짜 서버에 만들어져 있는 블로그 명
+	BlogName = "tistorytest"
+	// AccessToken 가짜 서버가 발급하는 Access Token
+	AccessToken = "tistorytest-access-token"
+	// ClientId 가짜 서버에 등록된 앱의 ClientId
+	ClientId = "tistorytest-client"
+	// SecretKey 가짜 서버에 등록된 앱의 SecretKey
+	SecretKey = "tistorytest-secret"
+	// RedirectUrl 가짜 서버에 등록된 앱의 RedirectUrl
+	RedirectUrl = "http://127.0.0.1/callback"
+	// AuthorizationCode 가짜 서버가 받아주는 Authorization Code (비어있지 않은 code 는 모두 받는다)
+	AuthorizationCode = "tistorytest-code"
+
+	// postPageSize 글 목록 한 페이지 글 수
+	postPageSize = 10
+	// maxCommentCount 최신 댓글 목록 한 페이지 최대 댓글 수
+	maxCommentCount = 10
+)
+
+// Server 가짜 Tistory 서버
+// 모든 상태는 메모리에만 있으며 서버마다 독립적이다.
+type Server struct {
+	*httptest.Server
+
+	// Now 글, 댓글 작성 시간으로 쓰는 시계 (기본값: time.Now)
+	// 요청을 보내기 전에만 바꿀 것
+	Now func() time.Time
+
+	mu          sync.Mutex
+	posts       []*post
+	comments    []*comment
+	categories  []*category
+	attachments []Attachment
+	nextId      int
+}
+
+// Attachment 업로드된 첨부 파일 기록
+// Name		업로드된 파일 이름
+// Size		파일 크기
+// Result	응답한 첨부 결과
+type Attachment struct {
+	// Name 업로드된 파일 이름
+	Name string
+
+	// Size 파일 크기
+	Size int64
+
+	// Result 응답한 첨부 결과
+	Result model.AttachResult
+}
+
+type post struct {
+	id            string
+	data          model.PostData
+	tags          []string
+	date          time.Time
+	published     time.Time
+	acceptComment model.CommentPolicy
+}
+
+type comment struct {
+	id       string
+	postId   string
+	parentId string
+	content  string
+	secret   model.CommentSecrecy
+	name     string
+	date     time.Time
+}
+
+type category struct {
+	id     string
+	name   string
+	parent string
+}
+
+// NewServer 가짜 Tistory 서버 시작
+// 다 쓰고 나면 Close 할 것
+func NewServer() *Server {
+	s := &Server{Now: time.Now, nextId: 1}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/access_token", s.handleAccessToken)
+	mux.HandleFunc("/apis/blog/info", s.api(http.MethodGet, s.handleBlogInfo))
+	mux.HandleFunc("/apis/post/list", s.api(http.MethodGet, s.handlePostList))
+	mux.HandleFunc("/apis/post/read", s.api(http.MethodGet, s.handlePostRead))
+	mux.HandleFunc("/apis/post/write", s.api(http.MethodPost, s.handlePostWrite))
+	mux.HandleFunc("/apis/post/modify", s.api(http.MethodPost, s.handlePostModify))
+	mux.HandleFunc("/apis/post/attach", s.api(http.MethodPost, s.handlePostAttach))
+	mux.HandleFunc("/apis/category/list", s.api(http.MethodGet, s.handleCategoryList))
+	mux.HandleFunc("/apis/comment/newest", s.api(http.MethodGet, s.handleCommentNewest))
+	mux.HandleFunc("/apis/comment/list", s.api(http.MethodGet, s.handleCommentList))
+	mux.HandleFunc("/apis/comment/write", s.api(http.MethodPost, s.handleCommentWrite))
+	mux.HandleFunc("/apis/comment/modify", s.api(http.MethodPost, s.handleCommentModify))
+	mux.HandleFunc("/apis/comment/delete", s.api(http.MethodPost, s.handleCommentDelete))
+
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// APIURL tistoryAPI.WithBaseURL 에 넘길 API 기본 URL
+func (s *Server) APIURL() string {
+	return s.URL + "/apis"
+}
+
+// OAuthURL tistoryAPI.WithOAuthURL 에 넘길 토큰 발급 URL
+func (s *Server) OAuthURL() string {
+	return s.URL + "/oauth/access_token"
+}
+
+// UserData 가짜 서버에 등록된 앱의 유저 데이터
+func (s *Server) UserData() model.UserData {
+	return model.UserData{
+		ClientId:          ClientId,
+		SecretKey:         SecretKey,
+		RedirectUrl:       RedirectUrl,
+		AuthorizationCode: AuthorizationCode,
+	}
+}
+
+// AddCategory 카테고리 추가 (parent 는 부모 카테고리 ID, 최상위면 빈 문자열)
+// return string	// 추가된 카테고리 ID
+func (s *Server) AddCategory(name, parent string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := &category{id: s.genId(), name: name, parent: parent}
+	s.categories = append(s.categories, c)
+	return c.id
+}
+
+// AddPost 글 추가 (API 를 거치지 않고 바로 저장한다)
+// return string	// 추가된 글 ID
+func (s *Server) AddPost(data model.PostData) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addPost(data).id
+}
+
+// AddComment 댓글 추가 (name 은 작성자 이름)
+// return string	// 추가된 댓글 ID
+func (s *Server) AddComment(data model.CommentData, name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addComment(data, name).id
+}
+
+// Attachments 지금까지 업로드된 첨부 파일 목록
+func (s *Server) Attachments() []Attachment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Attachment(nil), s.attachments...)
+}
+
+func (s *Server) genId() string {
+	id := strconv.Itoa(s.nextId)
+	s.nextId++
+	return id
+}
+
+func (s *Server) blogUrl() string {
+	return "https://" + BlogName + ".tistory.com"
+}
+
+func (s *Server) addPost(data model.PostData) *post {
+	now := s.Now()
+	p := &post{id: s.genId(), date: now, published: now, acceptComment: model.CommentAllowed}
+	s.applyPost(p, data)
+	s.posts = append(s.posts, p)
+	return p
+}
+
+// applyPost 보낸 값만 덮어쓴다. (실제 API 와 같이 빈 값은 기본값 취급)
+func (s *Server) applyPost(p *post, data model.PostData) {
+	if data.Title != "" {
+		p.data.Title = data.Title
+	}
+	if data.Content != "" {
+		p.data.Content = data.Content
+	}
+	if data.Visibility != "" {
+		p.data.Visibility = data.Visibility
+	}
+	if p.data.Visibility == "" {
+		p.data.Visibility = model.Private
+	}
+	if data.Category != "" {
+		p.data.Category = data.Category
+	}
+	if p.data.Category == "" {
+		p.data.Category = "0"
+	}
+	if data.Published != "" {
+		if sec, err := strconv.ParseInt(data.Published, 10, 64); err == nil {
+			p.published = time.Unix(sec, 0)
+		}
+	}
+	if data.Slogan != "" {
+		p.data.Slogan = data.Slogan
+	}
+	if data.Tag != "" {
+		p.tags = strings.Split(data.Tag, ",")
+	}
+	if data.AcceptComment != "" {
+		p.acceptComment = data.AcceptComment
+	}
+	if data.Password != "" {
+		p.data.Password = data.Password
+	}
+}
+
+func (s *Server) addComment(data model.CommentData, name string) *comment {
+	c := &comment{
+		id:       s.genId(),
+		postId:   data.PostId,
+		parentId: data.ParentId,
+		content:  data.Content,
+		secret:   data.Secret,
+		name:     name,
+		date:     s.Now(),
+	}
+	s.comments = append(s.comments, c)
+	return c
+}
+
+func (s *Server) findPost(id string) *post {
+	for _, p := range s.posts {
+		if p.id == id {
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *Server) findComment(postId, id string) (int, *comment) {
+	for i, c := range s.comments {
+		if c.postId == postId && c.id == id {
+			return i, c
+		}
+	}
+	return -1, nil
+}
+
+// apiError Tistory 실패 응답
+type apiError struct {
+	status  int
+	message string
+}
+
+func errorf(status int, format string, args ...any) *apiError {
+	return &apiError{status: status, message: fmt.Sprintf(format, args...)}
+}
+
+// api 공통 검사 (메서드, access_token, blogName) 후 핸들러 결과를 {"tistory": ...} 로 응답한다.
+func (s *Server) api(method string, handle func(r *http.Request) (any, *apiError)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			writeError(w, errorf(http.StatusMethodNotAllowed, "%s 요청만 허용됩니다.", method))
+			return
+		}
+		if err := r.ParseMultipartForm(32 << 20); err != nil && err != http.ErrNotMultipart {
+			writeError(w, errorf(http.StatusBadRequest, "요청을 읽을 수 없습니다: %v", err))
+			return
+		}
+		if r.FormValue("access_token") != AccessToken {
+			writeError(w, errorf(http.StatusUnauthorized, "access_token 이 유효하지 않습니다."))
+			return
+		}
+		if r.URL.Path != "/apis/blog/info" && r.FormValue("blogName") != BlogName {
+			writeError(w, errorf(http.StatusBadRequest, "블로그 정보가 없습니다. (blogName: %q)", r.FormValue("blogName")))
+			return
+		}
+
+		s.mu.Lock()
+		result, apiErr := handle(r)
+		s.mu.Unlock()
+
+		if apiErr != nil {
+			writeError(w, apiErr)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"tistory": result})
+	}
+}
+
+func writeError(w http.ResponseWriter, err *apiError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(err.status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"tistory": map[string]string{
+		"status":        strconv.Itoa(err.status),
+		"error_message": err.message,
+	}})
+}
+
+func (s *Server) handleAccessToken(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var errCode string
+	switch {
+	case query.Get("client_id") != ClientId || query.Get("client_secret") != SecretKey:
+		errCode = "invalid_client"
+	case query.Get("redirect_uri") != RedirectUrl:
+		errCode = "redirect_uri_mismatch"
+	case query.Get("code") == "":
+		errCode = "invalid_grant"
+	case query.Get("grant_type") != "authorization_code":
+		errCode = "unsupported_grant_type"
+	}
+
+	if errCode != "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, "error="+errCode)
+		return
+	}
+	_, _ = io.WriteString(w, "access_token="+AccessToken)
+}
+
+func (s *Server) handleBlogInfo(r *http.Request) (any, *apiError) {
+	return model.BlogResult{
+		Status: "200",
+		Item: model.BlogItem{
+			Id:     "tistorytest@example.com",
+			UserId: "1",
+			Blogs: []model.BlogItemListData{{
+				Name:     BlogName,
+				Url:      s.blogUrl(),
+				Nickname: "tistorytest",
+				Title:    "tistorytest blog",
+				Default:  true,
+				Role:     "소유자",
+				BlogId:   "1",
+				Statistics: model.BlogStatisticsData{
+					Post:    model.FlexInt(len(s.posts)),
+					Comment: model.FlexInt(len(s.comments)),
+				},
+			}},
+		},
+	}, nil
+}
+
+func (s *Server) handlePostList(r *http.Request) (any, *apiError) {
+	page, err := strconv.Atoi(r.FormValue("page"))
+	if err != nil || page < 1 {
+		return nil, errorf(http.StatusBadRequest, "page 는 1 이상이어야 합니다.")
+	}
+
+	// 최신 글 먼저
+	posts := make([]*post, len(s.posts))
+	for i, p := range s.posts {
+		posts[len(s.posts)-1-i] = p
+	}
+	start := min((page-1)*postPageSize, len(posts))
+	end := min(start+postPageSize, len(posts))
+
+	item := listPostItem{
+		PostListItem: model.PostListItem{
+			Url:        s.blogUrl(),
+			Page:       model.FlexInt(page),
+			Count:      model.FlexInt(end - start),
+			TotalCount: model.FlexInt(len(posts)),
+		},
+		Posts: []listPostData{},
+	}
+	for _, p := range posts[start:end] {
+		item.Posts = append(item.Posts, listPostData{
+			PostListItemData: model.PostListItemData{
+				Id:         p.id,
+				Title:      p.data.Title,
+				PostUrl:    s.blogUrl() + "/" + p.id,
+				CategoryId: p.data.Category,
+				Comments:   model.FlexInt(s.countComments(p.id)),
+				Trackbacks: 0,
+				Date:       model.NewDateTime(p.published),
+			},
+			Visibility: listVisibility(p.data.Visibility),
+		})
+	}
+	return struct {
+		Status string       `json:"status"`
+		Item   listPostItem `json:"item"`
+	}{Status: "200", Item: item}, nil
+}
+
+func (s *Server) handlePostRead(r *http.Request) (any, *apiError) {
+	p := s.findPost(r.FormValue("postId"))
+	if p == nil {
+		return nil, errorf(http.StatusNotFound, "존재하지 않는 글입니다.")
+	}
+
+	item := model.PostDetailItem{
+		Url:             s.blogUrl(),
+		Id:              p.id,
+		Title:           p.data.Title,
+		Content:         p.data.Content,
+		CategoryId:      p.data.Category,
+		PostUrl:         s.blogUrl() + "/" + p.id,
+		Visibility:      p.data.Visibility,
+		AcceptComment:   p.acceptComment,
+		AcceptTrackback: "1",
+		Comments:        model.FlexInt(s.countComments(p.id)),
+		Trackbacks:      0,
+		Date:            model.NewUnixTime(p.published),
+	}
+	item.Tags.Tag = append([]string{}, p.tags...)
+	return model.PostResult[model.PostDetailItem]{Status: "200", Item: item}, nil
+}
+
+func (s *Server) handlePostWrite(r *http.Request) (any, *apiError) {
+	data := postData(r)
+	if data.Title == "" {
+		return nil, errorf(http.StatusBadRequest, "title 은 필수입니다.")
+	}
+	p := s.addPost(data)
+	return model.PostWriteResult{Status: "200", PostId: p.id, Url: s.blogUrl() + "/" + p.id}, nil
+}
+
+func (s *Server) handlePostModify(r *http.Request) (any, *apiError) {
+	p := s.findPost(r.FormValue("postId"))
+	if p == nil {
+		return nil, errorf(http.StatusNotFound, "존재하지 않는 글입니다.")
+	}
+	data := postData(r)
+	if data.Title == "" {
+		return nil, errorf(http.StatusBadRequest, "title 은 필수입니다.")
+	}
+	// 실제 API 와 같이 글 수정은 전체 덮어쓰기다.
+	p.data = model.PostData{}
+	p.tags = nil
+	p.acceptComment = model.CommentAllowed
+	s.applyPost(p, data)
+	return model.PostWriteResult{Status: "200", PostId: p.id, Url: s.blogUrl() + "/" + p.id}, nil
+}
+
+func (s *Server) handlePostAttach(r *http.Request) (any, *apiError) {
+	file, header, err := r.FormFile("uploadedfile")
+	if err != nil {
+		return nil, errorf(http.StatusBadRequest, "uploadedfile 이 없습니다.")
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return nil, errorf(http.StatusBadRequest, "파일을 읽을 수 없습니다: %v", err)
+	}
+
+	name := hex.EncodeToString(hash.Sum(nil))[:16] + path.Ext(header.Filename)
+	result := model.AttachResult{
+		Status:   "200",
+		Url:      "https://cfile.tistory.com/image/" + name,
+		Replacer: "[##_1N|cfile" + strconv.Itoa(len(s.attachments)+1) + ".uf@" + name + "|width=\"100\"_##]",
+	}
+	s.attachments = append(s.attachments, Attachment{Name: header.Filename, Size: size, Result: result})
+	return result, nil
+}
+
+func (s *Server) handleCategoryList(r *http.Request) (any, *apiError) {
+	item := model.CategoryItem{Url: s.blogUrl(), Categories: []model.CategoryData{}}
+	for _, c := range s.categories {
+		entries := 0
+		for _, p := range s.posts {
+			if p.data.Category == c.id {
+				entries++
+			}
+		}
+		item.Categories = append(item.Categories, model.CategoryData{
+			Id:      c.id,
+			Name:    c.name,
+			Parent:  c.parent,
+			Label:   s.categoryLabel(c),
+			Entries: model.FlexInt(entries),
+		})
+	}
+	return model.CategoryResult{Status: "200", Item: item}, nil
+}
+
+// categoryLabel 부모/자식 형태의 카테고리 전체 이름
+func (s *Server) categoryLabel(c *category) string {
+	for _, parent := range s.categories {
+		if parent.id == c.parent {
+			return s.categoryLabel(parent) + "/" + c.name
+		}
+	}
+	return c.name
+}
+
+func (s *Server) handleCommentNewest(r *http.Request) (any, *apiError) {
+	page, err := strconv.Atoi(r.FormValue("page"))
+	if err != nil || page < 1 {
+		return nil, errorf(http.StatusBadRequest, "page 는 1 이상이어야 합니다.")
+	}
+	count := maxCommentCount
+	if raw := r.FormValue("count"); raw != "" {
+		if count, err = strconv.Atoi(raw); err != nil || count < 1 || count > maxCommentCount {
+			return nil, errorf(http.StatusBadRequest, "count 는 1 ~ %d 사이여야 합니다.", maxCommentCount)
+		}
+	}
+
+	comments := append([]*comment(nil), s.comments...)
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].date.After(comments[j].date)
+	})
+	start := min((page-1)*count, len(comments))
+	end := min(start+count, len(comments))
+
+	item := model.CommentNewestListItem{Url: s.blogUrl()}
+	item.Comments.Comment = []model.CommentNewestItemData{}
+	for _, c := range comments[start:end] {
+		item.Comments.Comment = append(item.Comments.Comment, model.CommentNewestItemData{
+			Id:      c.id,
+			Date:    model.NewUnixTime(c.date),
+			PostId:  c.postId,
+			Name:    c.name,
+			Comment: c.content,
+			Open:    open(c.secret),
+		})
+	}
+	return model.CommentResult[model.CommentNewestListItem]{Status: "200", Item: item}, nil
+}
+
+func (s *Server) handleCommentList(r *http.Request) (any, *apiError) {
+	postId := r.FormValue("postId")
+	if s.findPost(postId) == nil {
+		return nil, errorf(http.StatusNotFound, "존재하지 않는 글입니다.")
+	}
+
+	item := model.CommentListItem{Url: s.blogUrl(), PostId: postId}
+	item.Comments.Comment = []model.CommentListItemData{}
+	for _, c := range s.comments {
+		if c.postId != postId {
+			continue
+		}
+		item.Comments.Comment = append(item.Comments.Comment, model.CommentListItemData{
+			Id:         c.id,
+			Date:       model.NewUnixTime(c.date),
+			Name:       c.name,
+			ParentId:   c.parentId,
+			Visibility: "2",
+			Comment:    c.content,
+			Open:       open(c.secret),
+		})
+	}
+	item.TotalCount = model.FlexInt(len(item.Comments.Comment))
+	return model.CommentResult[model.CommentListItem]{Status: "200", Item: item}, nil
+}
+
+func (s *Server) handleCommentWrite(r *http.Request) (any, *apiError) {
+	data := commentData(r)
+	if s.findPost(data.PostId) == nil {
+		return nil, errorf(http.StatusNotFound, "존재하지 않는 글입니다.")
+	}
+	if data.Content == "" {
+		return nil, errorf(http.StatusBadRequest, "content 는 필수입니다.")
+	}
+	c := s.addComment(data, "tistorytest")
+	return model.CommentWriteResult{Status: "200", Result: "OK", CommentUrl: s.blogUrl() + "/" + c.postId + "#comment" + c.id}, nil
+}
+
+func (s *Server) handleCommentModify(r *http.Request) (any, *apiError) {
+	data := commentData(r)
+	_, c := s.findComment(data.PostId, r.FormValue("commentId"))
+	if c == nil {
+		return nil, errorf(http.StatusNotFound, "존재하지 않는 댓글입니다.")
+	}
+	if data.Content == "" {
+		return nil, errorf(http.StatusBadRequest, "content 는 필수입니다.")
+	}
+	c.content = data.Content
+	c.secret = data.Secret
+	if data.ParentId != "" {
+		c.parentId = data.ParentId
+	}
+	return model.CommentWriteResult{Status: "200", Result: "OK", CommentUrl: s.blogUrl() + "/" + c.postId + "#comment" + c.id}, nil
+}
+
+func (s *Server) handleCommentDelete(r *http.Request) (any, *apiError) {
+	i, c := s.findComment(r.FormValue("postId"), r.FormValue("commentId"))
+	if c == nil {
+		return nil, errorf(http.StatusNotFound, "존재하지 않는 댓글입니다.")
+	}
+	s.comments = append(s.comments[:i], s.comments[i+1:]...)
+	return model.CommentDeleteResult{Status: "200"}, nil
+}
+
+func (s *Server) countComments(postId string) int {
+	count := 0
+	for _, c := range s.comments {
+		if c.postId == postId {
+			count++
+		}
+	}
+	return count
+}
+
+func postData(r *http.Request) model.PostData {
+	return model.PostData{
+		BlogName:      r.FormValue("blogName"),
+		Title:         r.FormValue("title"),
+		Content:       r.FormValue("content"),
+		Visibility:    model.Visibility(r.FormValue("visibility")),
+		Category:      r.FormValue("category"),
+		Published:     r.FormValue("published"),
+		Slogan:        r.FormValue("slogan"),
+		Tag:           r.FormValue("tag"),
+		AcceptComment: model.CommentPolicy(r.FormValue("acceptComment")),
+		Password:      r.FormValue("password"),
+	}
+}
+
+func commentData(r *http.Request) model.CommentData {
+	return model.CommentData{
+		BlogName: r.FormValue("blogName"),
+		PostId:   r.FormValue("postId"),
+		ParentId: r.FormValue("parentId"),
+		Content:  r.FormValue("content"),
+		Secret:   model.CommentSecrecy(r.FormValue("secret")),
+	}
+}
+
+// listPostItem 글 목록 응답
+// 실제 API 와 같이 공개 여부를 글 목록 코드로 내려주기 위해 model.PostListItem 의 Posts 를 가린다.
+type listPostItem struct {
+	model.PostListItem
+	Posts []listPostData `json:"posts"`
+}
+
+type listPostData struct {
+	model.PostListItemData
+	Visibility string `json:"visibility"`
+}
+
+// listVisibility 글 목록 API 는 공개 여부를 다른 코드로 내려준다. (1 => 15, 3 => 20)
+func listVisibility(visibility model.Visibility) string {
+	switch visibility {
+	case model.Protected:
+		return "15"
+	case model.Public:
+		return "20"
+	}
+	return visibility.String()
+}
+
+func open(secret model.CommentSecrecy) model.YN {
+	return secret != model.CommentSecret
+}
@@ -0,0 +1,131 @@
+package tistoryAPI
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrEmptyToken TokenSource 가 빈 토큰을 반환한 경우
+	ErrEmptyToken = errors.New("tistoryAPI: empty access token")
+	// ErrInvalidTokenResponse 토큰 발급 응답에 access_token 도 error 도 없는 경우 (HTML 에러 페이지등)
+	ErrInvalidTokenResponse = errors.New("tistoryAPI: invalid access token response")
+)
+
+// TokenSource 엑세스 토큰 공급 인터페이스
+// 이전 실행에서 저장해둔 토큰을 재사용할 때 구현해서 NewServiceFromTokenSource 에 넘긴다.
+type TokenSource interface {
+	// Token 엑세스 토큰 반환
+	Token() (string, error)
+}
+
+// TokenSourceFunc 함수를 TokenSource 로 쓰기 위한 어댑터
+type TokenSourceFunc func() (string, error)
+
+func (f TokenSourceFunc) Token() (string, error) {
+	return f()
+}
+
+// StaticTokenSource 항상 같은 토큰을 반환하는 TokenSource
+func StaticTokenSource(token string) TokenSource {
+	return TokenSourceFunc(func() (string, error) {
+		return token, nil
+	})
+}
+
+// NewServiceWithToken 이미 발급받은 Access Token 으로 서비스를 생성한다.
+// Authorization Code 교환 과정(NewService)을 건너뛰므로 셀레니움 없이 재시작 할 수 있다.
+// 토큰은 GetACToken() 으로 꺼내 저장해 둘 수 있다.
+// @Params context.Context string ...Option	// 컨텍스트, Access Token, 설정 옵션
+// return Service, error
+func NewServiceWithToken(ctx context.Context, token string, opts ...Option) (Service, error) {
+	return NewServiceFromTokenSource(ctx, StaticTokenSource(token), opts...)
+}
+
+// NewServiceFromTokenSource TokenSource 에서 받은 Access Token 으로 서비스를 생성한다.
+// 토큰은 생성시 한번만 가져온다.
+// @Params context.Context TokenSource ...Option	// 컨텍스트, 토큰 공급자, 설정 옵션
+// return Service, error
+func NewServiceFromTokenSource(ctx context.Context, source TokenSource, opts ...Option) (Service, error) {
+
+	token, err := source.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, ErrEmptyToken
+	}
+
+	s := newService(ctx, newOptions(opts))
+	s.token = model.Token{AccessToken: token}
+	return s, nil
+}
+
+// exchangeToken Authorization Code 를 Access Token 으로 교환한다.
+func (s service) exchangeToken(ctx context.Context, oauthUrl string, userData model.UserData) (model.Token, error) {
+
+	query := url.Values{}
+	query.Set("client_id", userData.ClientId)
+	query.Set("client_secret", userData.SecretKey)
+	query.Set("redirect_uri", userData.RedirectUrl)
+	query.Set("code", userData.AuthorizationCode)
+	query.Set("grant_type", "authorization_code")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, oauthUrl+"?"+query.Encode(), nil)
+	if err != nil {
+		return model.Token{}, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return model.Token{}, err
+	}
+
+	defer resp.Body.Close()
+
+	all, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return model.Token{}, err
+	}
+
+	return parseTokenResponse(resp, all)
+}
+
+// parseTokenResponse 토큰 발급 응답 파싱
+// Tistory 는 access_token=xxx 형태로 응답하지만, 에러는 form, JSON 둘 다 올 수 있어 모두 처리한다.
+func parseTokenResponse(resp *http.Response, body []byte) (model.Token, error) {
+
+	raw := strings.TrimSpace(string(body))
+	fields := struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+
+	if strings.HasPrefix(raw, "{") {
+		if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+			return model.Token{}, fmt.Errorf("%w: %v", ErrInvalidTokenResponse, err)
+		}
+	} else if values, err := url.ParseQuery(raw); err == nil {
+		fields.AccessToken = values.Get("access_token")
+		fields.Error = values.Get("error")
+		fields.ErrorDescription = values.Get("error_description")
+	}
+
+	if fields.Error != "" {
+		return model.Token{}, &OAuthError{HTTPStatus: resp.StatusCode, Code: fields.Error, Description: fields.ErrorDescription}
+	}
+	if fields.AccessToken == "" || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return model.Token{}, fmt.Errorf("%w (http %d)", ErrInvalidTokenResponse, resp.StatusCode)
+	}
+
+	return model.Token{AccessToken: fields.AccessToken, ObtainedAt: time.Now(), Raw: raw}, nil
+}
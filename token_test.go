@@ -0,0 +1,135 @@
+package tistoryAPI
+
+import (
+	"context"
+	"errors"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewServiceWithToken(t *testing.T) {
+	var tokens []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.URL.Query().Get("access_token"))
+		_, _ = w.Write([]byte(`{"tistory":{"status":"200","item":{"id":"1"}}}`))
+	}))
+	defer srv.Close()
+
+	errSource := errors.New("저장소 오류")
+	tests := []struct {
+		name    string
+		source  TokenSource
+		wantErr error
+	}{
+		{
+			name:   "저장된 토큰 재사용:[success]",
+			source: StaticTokenSource("stored-token"),
+		},
+		{
+			name:    "빈 토큰:[failure]",
+			source:  StaticTokenSource(""),
+			wantErr: ErrEmptyToken,
+		},
+		{
+			name: "토큰 공급 실패:[failure]",
+			source: TokenSourceFunc(func() (string, error) {
+				return "", errSource
+			}),
+			wantErr: errSource,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens = nil
+			serv, err := NewServiceFromTokenSource(context.Background(), tt.source, WithBaseURL(srv.URL+"/apis"))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "stored-token", serv.GetACToken())
+
+			_, err = serv.GetBlogInfo()
+			assert.NoError(t, err)
+			// 토큰 교환 요청 없이 바로 API 를 호출한다.
+			assert.Equal(t, []string{"stored-token"}, tokens)
+		})
+	}
+
+	serv, err := NewServiceWithToken(context.Background(), "stored-token", WithBaseURL(srv.URL+"/apis"))
+	assert.NoError(t, err)
+	assert.Equal(t, "stored-token", serv.GetACToken())
+}
+
+func TestNewService_TokenResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		httpStatus  int
+		contentType string
+		body        string
+		want        string
+		wantErr     error
+	}{
+		{
+			name:       "form 응답:[success]",
+			httpStatus: http.StatusOK,
+			body:       "access_token=abc123",
+			want:       "abc123",
+		},
+		{
+			name:        "JSON 응답:[success]",
+			httpStatus:  http.StatusOK,
+			contentType: "application/json",
+			body:        `{"access_token":"abc123"}`,
+			want:        "abc123",
+		},
+		{
+			name:       "만료된 authorization code:[failure]",
+			httpStatus: http.StatusBadRequest,
+			body:       "error=invalid_grant&error_description=authorization+code+expired",
+			wantErr:    ErrInvalidGrant,
+		},
+		{
+			name:        "redirect uri 불일치 JSON:[failure]",
+			httpStatus:  http.StatusBadRequest,
+			contentType: "application/json",
+			body:        `{"error":"redirect_uri_mismatch","error_description":"redirect_uri 가 일치하지 않습니다."}`,
+			wantErr:     ErrRedirectURIMismatch,
+		},
+		{
+			name:        "HTML 에러 페이지:[failure]",
+			httpStatus:  http.StatusInternalServerError,
+			contentType: "text/html",
+			body:        "<html><body>error</body></html>",
+			wantErr:     ErrInvalidTokenResponse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "code", r.URL.Query().Get("code"))
+				if tt.contentType != "" {
+					w.Header().Set("Content-Type", tt.contentType)
+				}
+				w.WriteHeader(tt.httpStatus)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			serv, err := NewService(context.Background(), model.UserData{AuthorizationCode: "code"}, WithOAuthURL(srv.URL))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			token := serv.Token()
+			assert.Equal(t, tt.want, token.AccessToken)
+			assert.Equal(t, tt.body, token.Raw)
+			assert.False(t, token.ObtainedAt.IsZero())
+			assert.Equal(t, tt.want, serv.GetACToken())
+		})
+	}
+}
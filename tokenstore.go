@@ -0,0 +1,250 @@
+package tistoryAPI
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"golang.org/x/crypto/scrypt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrTokenNotFound 저장소에 토큰이 없는 경우
+var ErrTokenNotFound = errors.New("tistoryAPI: token not found")
+
+// TokenKey 토큰 저장 키
+// ClientId	Tistory Open API 에 블로그 등록시 발급받는 ClientId
+// Account	같은 앱으로 여러 계정(봇)을 쓸 때 구분하는 이름
+type TokenKey struct {
+	// ClientId Tistory Open API 에 블로그 등록시 발급받는 ClientId
+	ClientId string
+
+	// Account 같은 앱으로 여러 계정(봇)을 쓸 때 구분하는 이름
+	Account string
+}
+
+func (k TokenKey) String() string {
+	return k.ClientId + "/" + k.Account
+}
+
+// TokenStore 엑세스 토큰 저장소 인터페이스
+// WithTokenStore 옵션으로 NewService 에 넘기면 저장된 토큰을 재사용하고, 새로 발급받은 토큰을 저장한다.
+type TokenStore interface {
+	// Load 저장된 토큰 읽기 (없으면 ErrTokenNotFound)
+	Load(key TokenKey) (model.Token, error)
+	// Save 토큰 저장 (같은 키는 덮어쓴다)
+	Save(key TokenKey, token model.Token) error
+	// Delete 토큰 삭제 (없어도 에러가 아니다)
+	Delete(key TokenKey) error
+}
+
+// NewFileTokenStore JSON 파일 토큰 저장소
+// 파일은 0600 권한으로 만들어지며 토큰이 평문으로 저장되므로 공유 서버에서는 암호화 저장소를 쓸 것
+// @Param string	// 저장 파일 경로
+// return TokenStore
+func NewFileTokenStore(path string) TokenStore {
+	return &fileTokenStore{file: jsonFile[model.Token]{path: path}}
+}
+
+// NewEncryptedFileTokenStore AES-GCM 으로 암호화한 파일 토큰 저장소
+// 키는 passphrase 를 scrypt 로 유도해서 사용한다. (키링 불필요)
+// @Param string, []byte	// 저장 파일 경로, 암호
+// return TokenStore, error
+func NewEncryptedFileTokenStore(path string, passphrase []byte) (TokenStore, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("tistoryAPI: empty token store passphrase")
+	}
+	c := &gcmCodec{passphrase: passphrase}
+	return &fileTokenStore{file: jsonFile[model.Token]{path: path, seal: c.seal, open: c.open}}, nil
+}
+
+// NewEncryptedFileTokenStoreFromEnv 환경변수의 암호로 NewEncryptedFileTokenStore 를 생성한다.
+// @Param string, string	// 저장 파일 경로, 암호가 담긴 환경변수 명
+// return TokenStore, error
+func NewEncryptedFileTokenStoreFromEnv(path, envName string) (TokenStore, error) {
+	passphrase := os.Getenv(envName)
+	if passphrase == "" {
+		return nil, fmt.Errorf("tistoryAPI: token store passphrase env %s is empty", envName)
+	}
+	return NewEncryptedFileTokenStore(path, []byte(passphrase))
+}
+
+// fileTokenStore 키별 토큰을 하나의 JSON 파일에 저장한다.
+type fileTokenStore struct {
+	mu   sync.Mutex
+	file jsonFile[model.Token]
+}
+
+func (f *fileTokenStore) Load(key TokenKey) (model.Token, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tokens, err := f.file.read()
+	if err != nil {
+		return model.Token{}, err
+	}
+	token, ok := tokens[key.String()]
+	if !ok {
+		return model.Token{}, ErrTokenNotFound
+	}
+	return token, nil
+}
+
+func (f *fileTokenStore) Save(key TokenKey, token model.Token) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tokens, err := f.file.read()
+	if err != nil {
+		return err
+	}
+	tokens[key.String()] = token
+	return f.file.write(tokens)
+}
+
+func (f *fileTokenStore) Delete(key TokenKey) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tokens, err := f.file.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := tokens[key.String()]; !ok {
+		return nil
+	}
+	delete(tokens, key.String())
+	return f.file.write(tokens)
+}
+
+// jsonFile 키별 값을 하나의 JSON 파일에 저장한다. (fileTokenStore, fileAttachmentCache 공용)
+// seal, open 이 있으면 파일 내용을 암호화/복호화 한다. 잠금은 사용하는 쪽에서 한다.
+type jsonFile[V any] struct {
+	path string
+	seal func(plain []byte) ([]byte, error)
+	open func(data []byte) ([]byte, error)
+}
+
+// read 파일이 없으면 빈 목록을 반환한다.
+func (f jsonFile[V]) read() (map[string]V, error) {
+	values := map[string]V{}
+
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if f.open != nil {
+		if data, err = f.open(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// write 임시 파일에 쓴 뒤 rename 하여 중간에 실패해도 기존 파일이 깨지지 않게 한다.
+func (f jsonFile[V]) write(values map[string]V) error {
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return err
+	}
+	if f.seal != nil {
+		if data, err = f.seal(data); err != nil {
+			return err
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+// gcmCodec passphrase 기반 AES-256-GCM 암호화
+// 저장 형식: {"salt": ..., "nonce": ..., "data": ...} ([]byte 는 base64 로 인코딩된다)
+type gcmCodec struct {
+	passphrase []byte
+}
+
+type sealedFile struct {
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+func (c *gcmCodec) seal(plain []byte) ([]byte, error) {
+	sealed := sealedFile{Salt: make([]byte, 16)}
+	if _, err := io.ReadFull(rand.Reader, sealed.Salt); err != nil {
+		return nil, err
+	}
+
+	aead, err := c.aead(sealed.Salt)
+	if err != nil {
+		return nil, err
+	}
+	sealed.Nonce = make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, sealed.Nonce); err != nil {
+		return nil, err
+	}
+	sealed.Data = aead.Seal(nil, sealed.Nonce, plain, nil)
+	return json.Marshal(sealed)
+}
+
+func (c *gcmCodec) open(data []byte) ([]byte, error) {
+	sealed := sealedFile{}
+	if err := json.Unmarshal(data, &sealed); err != nil {
+		return nil, err
+	}
+
+	aead, err := c.aead(sealed.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed.Nonce) != aead.NonceSize() {
+		return nil, errors.New("tistoryAPI: malformed token store file")
+	}
+	plain, err := aead.Open(nil, sealed.Nonce, sealed.Data, nil)
+	if err != nil {
+		// 암호가 틀렸거나 파일이 변조된 경우
+		return nil, fmt.Errorf("tistoryAPI: decrypt token store: %w", err)
+	}
+	return plain, nil
+}
+
+func (c *gcmCodec) aead(salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(c.passphrase, salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,129 @@
+package tistoryAPI
+
+import (
+	"context"
+	"errors"
+	"github.com/fineroot1253/tistoryAPI/model"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTokenStore(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TISTORY_TEST_PASSPHRASE", "correct horse battery staple")
+
+	encrypted, err := NewEncryptedFileTokenStoreFromEnv(filepath.Join(dir, "encrypted.json"), "TISTORY_TEST_PASSPHRASE")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		store TokenStore
+		path  string
+	}{
+		{
+			name:  "JSON 파일 저장소:[success]",
+			store: NewFileTokenStore(filepath.Join(dir, "plain.json")),
+			path:  filepath.Join(dir, "plain.json"),
+		},
+		{
+			name:  "암호화 파일 저장소:[success]",
+			store: encrypted,
+			path:  filepath.Join(dir, "encrypted.json"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			botA := TokenKey{ClientId: "client", Account: "bot-a"}
+			botB := TokenKey{ClientId: "client", Account: "bot-b"}
+			token := model.Token{AccessToken: "token-a", ObtainedAt: time.Unix(1700000000, 0).UTC(), Raw: "access_token=token-a"}
+
+			_, err := tt.store.Load(botA)
+			assert.ErrorIs(t, err, ErrTokenNotFound)
+
+			assert.NoError(t, tt.store.Save(botA, token))
+			assert.NoError(t, tt.store.Save(botB, model.Token{AccessToken: "token-b"}))
+
+			got, err := tt.store.Load(botA)
+			assert.NoError(t, err)
+			assert.Equal(t, token, got)
+
+			info, err := os.Stat(tt.path)
+			assert.NoError(t, err)
+			assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+			assert.NoError(t, tt.store.Delete(botA))
+			_, err = tt.store.Load(botA)
+			assert.ErrorIs(t, err, ErrTokenNotFound)
+			got, err = tt.store.Load(botB)
+			assert.NoError(t, err)
+			assert.Equal(t, "token-b", got.AccessToken)
+		})
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "encrypted.json"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "token-b")
+
+	wrong, err := NewEncryptedFileTokenStore(filepath.Join(dir, "encrypted.json"), []byte("wrong"))
+	assert.NoError(t, err)
+	_, err = wrong.Load(TokenKey{ClientId: "client", Account: "bot-b"})
+	assert.Error(t, err)
+
+	_, err = NewEncryptedFileTokenStoreFromEnv(filepath.Join(dir, "encrypted.json"), "TISTORY_TEST_EMPTY_PASSPHRASE")
+	assert.Error(t, err)
+}
+
+func TestNewService_TokenStore(t *testing.T) {
+	exchanges := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exchanges++
+		_, _ = w.Write([]byte("access_token=fresh"))
+	}))
+	defer srv.Close()
+
+	store := NewFileTokenStore(filepath.Join(t.TempDir(), "tokens.json"))
+	userData := model.UserData{ClientId: "client", AuthorizationCode: "code"}
+
+	// 처음에는 발급받아 저장한다.
+	serv, err := NewService(context.Background(), userData, WithOAuthURL(srv.URL), WithTokenStore(store, "bot"))
+	assert.NoError(t, err)
+	assert.Equal(t, "fresh", serv.GetACToken())
+	assert.Equal(t, 1, exchanges)
+
+	saved, err := store.Load(TokenKey{ClientId: "client", Account: "bot"})
+	assert.NoError(t, err)
+	assert.Equal(t, "fresh", saved.AccessToken)
+
+	// 재시작시 저장된 토큰을 재사용한다.
+	serv, err = NewService(context.Background(), userData, WithOAuthURL(srv.URL), WithTokenStore(store, "bot"))
+	assert.NoError(t, err)
+	assert.Equal(t, "fresh", serv.GetACToken())
+	assert.Equal(t, 1, exchanges)
+}
+
+// failingTokenStore 저장이 항상 실패하는 토큰 저장소
+type failingTokenStore struct{}
+
+func (failingTokenStore) Load(TokenKey) (model.Token, error) { return model.Token{}, ErrTokenNotFound }
+func (failingTokenStore) Save(TokenKey, model.Token) error   { return errors.New("disk full") }
+func (failingTokenStore) Delete(TokenKey) error              { return nil }
+
+func TestNewService_TokenStoreSaveFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("access_token=fresh"))
+	}))
+	defer srv.Close()
+
+	// 저장에 실패해도 이미 쓴 Authorization Code 로 받은 토큰은 버리지 않는다.
+	userData := model.UserData{ClientId: "client", AuthorizationCode: "code"}
+	serv, err := NewService(context.Background(), userData, WithOAuthURL(srv.URL), WithTokenStore(failingTokenStore{}, "bot"))
+	assert.ErrorContains(t, err, "disk full")
+	if assert.NotNil(t, serv) {
+		assert.Equal(t, "fresh", serv.GetACToken())
+	}
+}